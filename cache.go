@@ -0,0 +1,80 @@
+package order
+
+import (
+	"fmt"
+)
+
+// EvictingCache holds at most a given number of values. When a value is added to a full cache, the
+// smallest value, according to the comparison functions, is evicted. It can be used to keep the N
+// greatest values that were seen. An EvictingCache is not safe for concurrent use.
+type EvictingCache struct {
+	capacity int
+	list     *SortedList
+}
+
+// NewEvictingCache returns an empty EvictingCache of values of type T, that holds at most capacity
+// values, ordered by the given comparison functions. It panics if capacity is not positive.
+func NewEvictingCache(capacity int, fns Fns) *EvictingCache {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("capacity should be positive, got: %d", capacity))
+	}
+	return &EvictingCache{capacity: capacity, list: NewSortedList(fns)}
+}
+
+// Add adds a value to the cache. If the cache exceeds its capacity, the smallest value is evicted
+// and returned with ok set to true. Among equal smallest values, the value that was added first is
+// evicted. The evicted value might be the added value itself, if it is smaller than all the values
+// in a full cache.
+func (c *EvictingCache) Add(value interface{}) (evicted interface{}, ok bool) {
+	c.list.Insert(value)
+	if c.list.Len() <= c.capacity {
+		return nil, false
+	}
+	evicted = c.list.At(0)
+	c.list.deleteAt(0)
+	return evicted, true
+}
+
+// Min returns the smallest value in the cache, which is the next value to be evicted. It panics if
+// the cache is empty.
+func (c *EvictingCache) Min() interface{} {
+	return c.list.At(0)
+}
+
+// Max returns the greatest value in the cache. It panics if the cache is empty.
+func (c *EvictingCache) Max() interface{} {
+	return c.list.At(c.list.Len() - 1)
+}
+
+// Contains returns whether the cache contains a value that is equal to the given value.
+func (c *EvictingCache) Contains(value interface{}) bool {
+	return c.list.Contains(value)
+}
+
+// Len returns the number of values in the cache.
+func (c *EvictingCache) Len() int {
+	return c.list.Len()
+}
+
+// Cap returns the capacity of the cache.
+func (c *EvictingCache) Cap() int {
+	return c.capacity
+}
+
+// Range calls f sequentially for each value in the cache, in increasing order. If f returns false,
+// range stops the iteration. The cache should not be modified during the iteration.
+func (c *EvictingCache) Range(f func(value interface{}) bool) {
+	c.list.Range(func(_ int, value interface{}) bool { return f(value) })
+}
+
+// AppendTo appends the values of the cache, in order, to the slice that dstPtr points to. See
+// SortedList.AppendTo.
+func (c *EvictingCache) AppendTo(dstPtr interface{}) {
+	c.list.AppendTo(dstPtr)
+}
+
+// CopyRange sets the slice that dstPtr points to, to the values of the cache that are greater or
+// equal to lo, and less than hi, in order. See SortedList.CopyRange.
+func (c *EvictingCache) CopyRange(dstPtr interface{}, lo, hi interface{}) {
+	c.list.CopyRange(dstPtr, lo, hi)
+}
@@ -0,0 +1,66 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvictingCache(t *testing.T) {
+	t.Parallel()
+
+	c := NewEvictingCache(3, intFn)
+	assert.Equal(t, 3, c.Cap())
+	assert.Equal(t, 0, c.Len())
+
+	for _, v := range []int{5, 1, 3} {
+		_, ok := c.Add(v)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, 1, c.Min())
+	assert.Equal(t, 5, c.Max())
+
+	// Evict the smallest value.
+	evicted, ok := c.Add(4)
+	assert.True(t, ok)
+	assert.Equal(t, 1, evicted)
+	assert.False(t, c.Contains(1))
+	assert.True(t, c.Contains(4))
+
+	// Adding a value smaller than all the values evicts the value itself.
+	evicted, ok = c.Add(0)
+	assert.True(t, ok)
+	assert.Equal(t, 0, evicted)
+
+	var got []int
+	c.Range(func(v interface{}) bool {
+		got = append(got, v.(int))
+		return true
+	})
+	assert.Equal(t, []int{3, 4, 5}, got)
+}
+
+func TestEvictingCache_evictFirstAdded(t *testing.T) {
+	t.Parallel()
+
+	intp := func(i int) *int { return &i }
+
+	first, second := intp(1), intp(1)
+	c := NewEvictingCache(2, intFn)
+	c.Add(first)
+	c.Add(second)
+	evicted, _ := c.Add(intp(2))
+	assert.True(t, evicted == first)
+}
+
+func TestEvictingCache_invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { NewEvictingCache(0, intFn) })
+
+	c := NewEvictingCache(1, intFn)
+	assert.Panics(t, func() { c.Min() })
+	assert.Panics(t, func() { c.Max() })
+	assert.Panics(t, func() { c.Add("1") })
+}
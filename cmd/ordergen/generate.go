@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+)
+
+// field is a field of the generated comparison function.
+type field struct {
+	name       string
+	descending bool
+	kind       fieldKind
+}
+
+// fieldKind defines how a field is compared.
+type fieldKind int
+
+const (
+	kindOrdered fieldKind = iota // Compared with the < and > operators.
+	kindFloat                    // Compared with the < and > operators, NaN values last.
+	kindBool                     // False is less than true.
+	kindTime                     // Compared with the Before and After methods.
+	kindBytes                    // Compared with bytes.Compare.
+	kindCompare                  // Compared with a Compare method.
+	kindCmp                      // Compared with a Cmp method.
+)
+
+// generate returns the source of the comparison and sort functions of the given type, in the
+// package of the given directory.
+func generate(dir, typeName string, fieldNames []string) ([]byte, error) {
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s not found in package %s", typeName, pkg.Name())
+	}
+	if _, ok := obj.(*types.TypeName); !ok {
+		return nil, fmt.Errorf("%s is not a type", typeName)
+	}
+	if _, ok := obj.Type().Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("%s is not a struct type", typeName)
+	}
+
+	fields := make([]field, 0, len(fieldNames))
+	for _, name := range fieldNames {
+		f, err := newField(pkg, obj.Type(), strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %v", name, err)
+		}
+		fields = append(fields, f)
+	}
+
+	var b bytes.Buffer
+	writeSource(&b, pkg.Name(), typeName, fields)
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+// loadPackage parses and type checks the non-test Go files of the package in the given directory.
+func loadPackage(dir string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if strings.HasSuffix(name, "_order.go") {
+			// Skip previously generated files, which might be stale.
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(bp.ImportPath, fset, files, nil)
+}
+
+// newField returns a field of the given struct type by its name, which is descending if the name has
+// a leading '-'.
+func newField(pkg *types.Package, tp types.Type, name string) (field, error) {
+	f := field{name: strings.TrimPrefix(name, "-"), descending: strings.HasPrefix(name, "-")}
+	obj, _, _ := types.LookupFieldOrMethod(tp, false, pkg, f.name)
+	v, ok := obj.(*types.Var)
+	if !ok || !v.IsField() {
+		return f, fmt.Errorf("no such field")
+	}
+	kind, err := kindOf(pkg, v.Type())
+	if err != nil {
+		return f, err
+	}
+	f.kind = kind
+	return f, nil
+}
+
+// kindOf returns how values of the given type are compared.
+func kindOf(pkg *types.Package, tp types.Type) (fieldKind, error) {
+	// The time.Time is checked first, since its methods depend on the Go version.
+	if named, ok := tp.(*types.Named); ok {
+		if obj := named.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			return kindTime, nil
+		}
+	}
+	// As in the order package, a comparison method takes precedence.
+	for _, m := range []struct {
+		name string
+		kind fieldKind
+	}{{"Compare", kindCompare}, {"Cmp", kindCmp}} {
+		if hasCompareMethod(pkg, tp, m.name) {
+			return m.kind, nil
+		}
+	}
+	switch u := tp.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			return kindBool, nil
+		case info&types.IsFloat != 0:
+			return kindFloat, nil
+		case info&types.IsOrdered != 0:
+			return kindOrdered, nil
+		}
+	case *types.Slice:
+		if elem, ok := u.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Byte {
+			return kindBytes, nil
+		}
+	}
+	return 0, fmt.Errorf("type %v is not supported", tp)
+}
+
+// hasCompareMethod returns whether the type has a method of the form `func (T) <name>(T) int`.
+func hasCompareMethod(pkg *types.Package, tp types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(tp, false, pkg, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	result, ok := sig.Results().At(0).Type().(*types.Basic)
+	return ok && result.Kind() == types.Int && types.Identical(sig.Params().At(0).Type(), tp)
+}
+
+// writeSource writes the generated source of the comparison and sort functions.
+func writeSource(b *bytes.Buffer, pkgName, typeName string, fields []field) {
+	imports := []string{`"sort"`}
+	for _, f := range fields {
+		if f.kind == kindBytes {
+			imports = append([]string{`"bytes"`}, imports...)
+			break
+		}
+	}
+
+	fmt.Fprintf(b, "// Code generated by ordergen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package %s\n\n", pkgName)
+	fmt.Fprintf(b, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+
+	fmt.Fprintf(b, "// compare%[1]s is a three-way comparison of %[1]s values.\n", typeName)
+	fmt.Fprintf(b, "func compare%[1]s(a, b %[1]s) int {\n", typeName)
+	for _, f := range fields {
+		lhs, rhs := "a."+f.name, "b."+f.name
+		if f.descending {
+			lhs, rhs = rhs, lhs
+		}
+		writeFieldCompare(b, f.kind, lhs, rhs)
+	}
+	fmt.Fprintf(b, "return 0\n}\n\n")
+
+	fmt.Fprintf(b, "// sort%[1]s sorts a slice of %[1]s values.\n", typeName)
+	fmt.Fprintf(b, "func sort%[1]s(s []%[1]s) {\nsort.Sort(sorter%[1]s(s))\n}\n\n", typeName)
+	fmt.Fprintf(b, "// sortStable%[1]s sorts a slice of %[1]s values, keeping the order of equal values.\n", typeName)
+	fmt.Fprintf(b, "func sortStable%[1]s(s []%[1]s) {\nsort.Stable(sorter%[1]s(s))\n}\n\n", typeName)
+	fmt.Fprintf(b, "// search%[1]s returns the index of the first value in a sorted slice of %[1]s values that is\n", typeName)
+	fmt.Fprintf(b, "// greater or equal to v, or the length of the slice if there is no such value.\n")
+	fmt.Fprintf(b, "func search%[1]s(s []%[1]s, v %[1]s) int {\n", typeName)
+	fmt.Fprintf(b, "return sort.Search(len(s), func(i int) bool { return compare%s(s[i], v) >= 0 })\n}\n\n", typeName)
+	fmt.Fprintf(b, "// sorter%[1]s implements sort.Interface for a slice of %[1]s values.\n", typeName)
+	fmt.Fprintf(b, "type sorter%[1]s []%[1]s\n\n", typeName)
+	fmt.Fprintf(b, "func (s sorter%s) Len() int { return len(s) }\n", typeName)
+	fmt.Fprintf(b, "func (s sorter%[1]s) Less(i, j int) bool { return compare%[1]s(s[i], s[j]) < 0 }\n", typeName)
+	fmt.Fprintf(b, "func (s sorter%s) Swap(i, j int) { s[i], s[j] = s[j], s[i] }\n", typeName)
+}
+
+// writeFieldCompare writes the comparison of a field, which returns if the field values differ.
+func writeFieldCompare(b *bytes.Buffer, kind fieldKind, lhs, rhs string) {
+	switch kind {
+	case kindOrdered:
+		fmt.Fprintf(b, "switch {\ncase %[1]s < %[2]s:\nreturn -1\ncase %[1]s > %[2]s:\nreturn 1\n}\n", lhs, rhs)
+	case kindFloat:
+		fmt.Fprintf(b, "switch lnan, rnan := %[1]s != %[1]s, %[2]s != %[2]s; {\n", lhs, rhs)
+		fmt.Fprintf(b, "case lnan && !rnan:\nreturn 1\ncase !lnan && rnan:\nreturn -1\n")
+		fmt.Fprintf(b, "case %[1]s < %[2]s:\nreturn -1\ncase %[1]s > %[2]s:\nreturn 1\n}\n", lhs, rhs)
+	case kindBool:
+		fmt.Fprintf(b, "if %[1]s != %[2]s {\nif %[1]s {\nreturn 1\n}\nreturn -1\n}\n", lhs, rhs)
+	case kindTime:
+		fmt.Fprintf(b, "switch {\ncase %[1]s.Before(%[2]s):\nreturn -1\ncase %[1]s.After(%[2]s):\nreturn 1\n}\n", lhs, rhs)
+	case kindBytes:
+		fmt.Fprintf(b, "if c := bytes.Compare(%s, %s); c != 0 {\nreturn c\n}\n", lhs, rhs)
+	case kindCompare:
+		fmt.Fprintf(b, "if c := %s.Compare(%s); c != 0 {\nreturn c\n}\n", lhs, rhs)
+	case kindCmp:
+		fmt.Fprintf(b, "if c := %s.Cmp(%s); c != 0 {\nreturn c\n}\n", lhs, rhs)
+	}
+}
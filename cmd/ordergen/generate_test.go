@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "Update golden files.")
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("testdata", "person")
+	got, err := generate(dir, "Person", []string{"Name", "-Age", "Score", "Admin", "Joined", "ID", "Version", "notes"})
+	require.NoError(t, err)
+
+	golden := filepath.Join(dir, "person_order.go")
+	if *update {
+		require.NoError(t, ioutil.WriteFile(golden, got, 0644))
+	}
+	want, err := ioutil.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_errors(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("testdata", "person")
+	tests := []struct {
+		name     string
+		dir      string
+		typeName string
+		fields   []string
+	}{
+		{name: "no package", dir: filepath.Join("testdata", "none"), typeName: "Person", fields: []string{"Name"}},
+		{name: "unknown type", dir: dir, typeName: "Unknown", fields: []string{"Name"}},
+		{name: "not a type", dir: dir, typeName: "MaxAge", fields: []string{"Name"}},
+		{name: "not a struct", dir: dir, typeName: "Names", fields: []string{"Name"}},
+		{name: "unknown field", dir: dir, typeName: "Person", fields: []string{"Unknown"}},
+		{name: "method instead of field", dir: dir, typeName: "Version", fields: []string{"Compare"}},
+		{name: "unsupported field type", dir: dir, typeName: "Person", fields: []string{"Tags"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(tt.dir, tt.typeName, tt.fields)
+			assert.Error(t, err)
+		})
+	}
+}
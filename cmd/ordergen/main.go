@@ -0,0 +1,55 @@
+// Ordergen generates reflection-free comparison and sort functions of a struct type, with the
+// semantics of the order package, for hot paths where the reflection based functions are too slow.
+//
+// Usage:
+//
+//	//go:generate go run github.com/posener/order/cmd/ordergen -type Person -fields Name,-Age
+//
+// For a type `Person`, it generates in the file person_order.go the functions:
+//
+//	func comparePerson(a, b Person) int
+//	func sortPerson(s []Person)
+//	func sortStablePerson(s []Person)
+//	func searchPerson(s []Person, v Person) int
+//
+// The fields are compared in order, where a field name with a leading '-' is compared in descending
+// order. A field type can be a numeric type, a string, a bool, a time.Time, a []byte, or a type
+// with a `func (T) Compare(T) int` or a `func (T) Cmp(T) int` method. As in the order package,
+// floating point NaN values are ordered after all other values.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		typeName = flag.String("type", "", "Name of the struct type (required).")
+		fields   = flag.String("fields", "", "Comma separated field names, prefix with '-' for descending order (required).")
+		output   = flag.String("output", "", "Output file name (default <type>_order.go).")
+		dir      = flag.String("dir", ".", "Directory of the package of the type.")
+	)
+	flag.Parse()
+	if *typeName == "" || *fields == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(*dir, *typeName, strings.Split(*fields, ","))
+	if err != nil {
+		log.Fatalf("ordergen: %v", err)
+	}
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_order.go"
+	}
+	if err := ioutil.WriteFile(filepath.Join(*dir, *output), src, 0644); err != nil {
+		log.Fatalf("ordergen: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "ordergen: wrote %s\n", *output)
+}
@@ -0,0 +1,28 @@
+package person
+
+import "time"
+
+const MaxAge = 150
+
+type Person struct {
+	Name    string
+	Age     int
+	Score   float64
+	Admin   bool
+	Joined  time.Time
+	ID      []byte
+	Version Version
+	Tags    map[string]bool
+	notes   string
+}
+
+type Names []string
+
+type Version struct{ Major, Minor int }
+
+func (v Version) Compare(other Version) int {
+	if v.Major != other.Major {
+		return v.Major - other.Major
+	}
+	return v.Minor - other.Minor
+}
@@ -0,0 +1,82 @@
+// Code generated by ordergen; DO NOT EDIT.
+
+package person
+
+import (
+	"bytes"
+	"sort"
+)
+
+// comparePerson is a three-way comparison of Person values.
+func comparePerson(a, b Person) int {
+	switch {
+	case a.Name < b.Name:
+		return -1
+	case a.Name > b.Name:
+		return 1
+	}
+	switch {
+	case b.Age < a.Age:
+		return -1
+	case b.Age > a.Age:
+		return 1
+	}
+	switch lnan, rnan := a.Score != a.Score, b.Score != b.Score; {
+	case lnan && !rnan:
+		return 1
+	case !lnan && rnan:
+		return -1
+	case a.Score < b.Score:
+		return -1
+	case a.Score > b.Score:
+		return 1
+	}
+	if a.Admin != b.Admin {
+		if a.Admin {
+			return 1
+		}
+		return -1
+	}
+	switch {
+	case a.Joined.Before(b.Joined):
+		return -1
+	case a.Joined.After(b.Joined):
+		return 1
+	}
+	if c := bytes.Compare(a.ID, b.ID); c != 0 {
+		return c
+	}
+	if c := a.Version.Compare(b.Version); c != 0 {
+		return c
+	}
+	switch {
+	case a.notes < b.notes:
+		return -1
+	case a.notes > b.notes:
+		return 1
+	}
+	return 0
+}
+
+// sortPerson sorts a slice of Person values.
+func sortPerson(s []Person) {
+	sort.Sort(sorterPerson(s))
+}
+
+// sortStablePerson sorts a slice of Person values, keeping the order of equal values.
+func sortStablePerson(s []Person) {
+	sort.Stable(sorterPerson(s))
+}
+
+// searchPerson returns the index of the first value in a sorted slice of Person values that is
+// greater or equal to v, or the length of the slice if there is no such value.
+func searchPerson(s []Person, v Person) int {
+	return sort.Search(len(s), func(i int) bool { return comparePerson(s[i], v) >= 0 })
+}
+
+// sorterPerson implements sort.Interface for a slice of Person values.
+type sorterPerson []Person
+
+func (s sorterPerson) Len() int           { return len(s) }
+func (s sorterPerson) Less(i, j int) bool { return comparePerson(s[i], s[j]) < 0 }
+func (s sorterPerson) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
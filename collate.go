@@ -0,0 +1,22 @@
+package order
+
+import (
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Collate returns comparison functions of strings according to the collation rules of the given
+// language, such that text is ordered as expected by the users of that language, rather than by byte
+// order. The options are passed to collate.New, for example collate.IgnoreCase or collate.Loose.
+func Collate(tag language.Tag, opts ...collate.Option) Fns {
+	c := collate.New(tag, opts...)
+	// A collator is not safe for concurrent use.
+	var mu sync.Mutex
+	return By(func(a, b string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.CompareString(a, b)
+	})
+}
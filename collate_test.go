@@ -0,0 +1,23 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func TestCollate(t *testing.T) {
+	t.Parallel()
+
+	// In German, "ä" is ordered as "a", and in Swedish it is ordered after "z".
+	words := []string{"zebra", "ära", "arg"}
+	Collate(language.German).Sort(words)
+	assert.Equal(t, []string{"ära", "arg", "zebra"}, words)
+	Collate(language.Swedish).Sort(words)
+	assert.Equal(t, []string{"arg", "zebra", "ära"}, words)
+
+	assert.True(t, Collate(language.English, collate.IgnoreCase).Is("Go").Equal("go"))
+	assert.True(t, Collate(language.English).Is("Go").Greater("go"))
+}
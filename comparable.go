@@ -2,9 +2,14 @@ package order
 
 import (
 	"bytes"
+	"container/heap"
 	"fmt"
+	"math/big"
+	"net"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/posener/order/internal/reflectutil"
@@ -28,6 +33,26 @@ func SortStable(slice interface{}) {
 	compareableSlice(reflect.ValueOf(slice)).SortStable(slice)
 }
 
+// SortAuto sorts a Slice<T> if T implements a `func (T) Compare(T) int`, choosing the sorting
+// algorithm according to the given slice. See Fn.SortAuto. It panics if slice does not implement
+// the compare function.
+func SortAuto(slice interface{}) {
+	compareableSlice(reflect.ValueOf(slice)).SortAuto(slice)
+}
+
+// Sorted returns a sorted copy of a Slice<T> if T implements a `func (T) Compare(T) int`. See
+// Fn.Sorted. It panics if slice does not implement the compare function.
+func Sorted(slice interface{}) interface{} {
+	return compareableSlice(reflect.ValueOf(slice)).Sorted(slice)
+}
+
+// SortedStable returns a stable sorted copy of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.SortedStable. It panics if slice does not implement the compare
+// function.
+func SortedStable(slice interface{}) interface{} {
+	return compareableSlice(reflect.ValueOf(slice)).SortedStable(slice)
+}
+
 // Search a Slice<T> if T implements a `func (T) Compare(T) int` for a value. See Fn.Search.
 func Search(slice, value interface{}) int {
 	return compareableSlice(reflect.ValueOf(slice)).Search(slice, value)
@@ -52,12 +77,243 @@ func IsStrictSorted(slice interface{}) bool {
 	return compareableSlice(reflect.ValueOf(slice)).IsStrictSorted(slice)
 }
 
+// IsSortedWithin returns whether every element of a Slice<T> if T implements a
+// `func (T) Compare(T) int` is at most k positions away from its sorted position. See
+// Fn.IsSortedWithin. It panics if slice does not implement the compare function.
+func IsSortedWithin(slice interface{}, k int) bool {
+	return compareableSlice(reflect.ValueOf(slice)).IsSortedWithin(slice, k)
+}
+
 // Select applies select-k algorithm on a Slice<T> if T implements a `func (T) Compare(T) int`. See
 // Fn.Select. It panics if slice does not implement the compare function.
 func Select(slice interface{}, k int) {
 	compareableSlice(reflect.ValueOf(slice)).Select(slice, k)
 }
 
+// SymmetricDifference returns the elements that are present in exactly one of two sorted Slice<T>
+// if T implements a `func (T) Compare(T) int`. See Fn.SymmetricDifference. It panics if slice does
+// not implement the compare function.
+func SymmetricDifference(a, b interface{}) interface{} {
+	return compareableSlice(reflect.ValueOf(a)).SymmetricDifference(a, b)
+}
+
+// Diff returns the elements that were added and removed between two sorted Slice<T> if T implements
+// a `func (T) Compare(T) int`. See Fn.Diff. It panics if slice does not implement the compare
+// function.
+func Diff(old, new interface{}) (added, removed interface{}) {
+	return compareableSlice(reflect.ValueOf(old)).Diff(old, new)
+}
+
+// Join performs a sort-merge join of two sorted Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Join. It panics if slice does not implement the compare
+// function.
+func Join(a, b interface{}, emit func(i, j int)) {
+	compareableSlice(reflect.ValueOf(a)).Join(a, b, emit)
+}
+
+// Compact removes consecutive equal elements of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Compact. It panics if slice does not implement the compare
+// function.
+func Compact(slice interface{}) interface{} {
+	return compareableSlice(reflect.ValueOf(slice)).Compact(slice)
+}
+
+// Runs returns the runs of consecutive equal elements of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Runs. It panics if slice does not implement the compare
+// function.
+func Runs(slice interface{}) []Run {
+	return compareableSlice(reflect.ValueOf(slice)).Runs(slice)
+}
+
+// Boundaries returns the indices in which the value changes in a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Boundaries. It panics if slice does not implement the compare
+// function.
+func Boundaries(slice interface{}) []int {
+	return compareableSlice(reflect.ValueOf(slice)).Boundaries(slice)
+}
+
+// Insert inserts a value into a sorted Slice<T> if T implements a `func (T) Compare(T) int`. See
+// Fn.Insert. It panics if slice does not implement the compare function.
+func Insert(slice, value interface{}) interface{} {
+	return compareableSlice(reflect.ValueOf(slice)).Insert(slice, value)
+}
+
+// Remove removes the first element that is equal to a value from a sorted Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Remove. It panics if slice does not implement the compare
+// function.
+func Remove(slice, value interface{}) (interface{}, bool) {
+	return compareableSlice(reflect.ValueOf(slice)).Remove(slice, value)
+}
+
+// RemoveLast removes the last element that is equal to a value from a sorted Slice<T> if T
+// implements a `func (T) Compare(T) int`. See Fn.RemoveLast. It panics if slice does not implement
+// the compare function.
+func RemoveLast(slice, value interface{}) (interface{}, bool) {
+	return compareableSlice(reflect.ValueOf(slice)).RemoveLast(slice, value)
+}
+
+// RemoveAll removes all the elements that are equal to a value from a sorted Slice<T> if T
+// implements a `func (T) Compare(T) int`. See Fn.RemoveAll. It panics if slice does not implement
+// the compare function.
+func RemoveAll(slice, value interface{}) (interface{}, int) {
+	return compareableSlice(reflect.ValueOf(slice)).RemoveAll(slice, value)
+}
+
+// Deltas classifies each pair of adjacent elements of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Deltas. It panics if slice does not implement the compare
+// function.
+func Deltas(slice interface{}) []int {
+	return compareableSlice(reflect.ValueOf(slice)).Deltas(slice)
+}
+
+// MaxIncreasingStreak returns the longest strictly increasing run of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.MaxIncreasingStreak. It panics if slice does not implement the
+// compare function.
+func MaxIncreasingStreak(slice interface{}) (start, length int) {
+	return compareableSlice(reflect.ValueOf(slice)).MaxIncreasingStreak(slice)
+}
+
+// EqualFrequencyBuckets returns boundary values that split a Slice<T> if T implements a
+// `func (T) Compare(T) int` to k buckets of about the same size. See Fn.EqualFrequencyBuckets. It
+// panics if slice does not implement the compare function.
+func EqualFrequencyBuckets(slice interface{}, k int) []interface{} {
+	return compareableSlice(reflect.ValueOf(slice)).EqualFrequencyBuckets(slice, k)
+}
+
+// Heap returns a heap.Interface over a Slice<T> if T implements a `func (T) Compare(T) int`. See
+// Fn.Heap. It panics if slice does not implement the compare function.
+func Heap(slice interface{}) heap.Interface {
+	return compareableSlice(reflect.ValueOf(slice)).Heap(slice)
+}
+
+// IsHeap returns whether a Slice<T> if T implements a `func (T) Compare(T) int` is a min-heap. See
+// Fn.IsHeap. It panics if slice does not implement the compare function.
+func IsHeap(slice interface{}) bool {
+	return compareableSlice(reflect.ValueOf(slice)).IsHeap(slice)
+}
+
+// Argsort returns the indices that sort a Slice<T> if T implements a `func (T) Compare(T) int`. See
+// Fn.Argsort. It panics if slice does not implement the compare function.
+func Argsort(slice interface{}) []int {
+	return compareableSlice(reflect.ValueOf(slice)).Argsort(slice)
+}
+
+// SortTogether sorts a Slice<T> if T implements a `func (T) Compare(T) int`, and applies the same
+// reordering to the other slices. See Fn.SortTogether. It panics if keys does not implement the
+// compare function.
+func SortTogether(keys interface{}, others ...interface{}) {
+	compareableSlice(reflect.ValueOf(keys)).SortTogether(keys, others...)
+}
+
+// Ranks returns the rank of each element of a Slice<T> if T implements a `func (T) Compare(T) int`.
+// See Fn.Ranks. It panics if slice does not implement the compare function.
+func Ranks(slice interface{}, ties Ties) []float64 {
+	return compareableSlice(reflect.ValueOf(slice)).Ranks(slice, ties)
+}
+
+// Rank returns the number of elements that are less than a value in a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Rank. It panics if slice does not implement the compare
+// function.
+func Rank(slice, value interface{}) int {
+	return compareableSlice(reflect.ValueOf(slice)).Rank(slice, value)
+}
+
+// Inversions returns the number of out of order pairs in a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Inversions. It panics if slice does not implement the compare
+// function.
+func Inversions(slice interface{}) int {
+	return compareableSlice(reflect.ValueOf(slice)).Inversions(slice)
+}
+
+// LongestSortedRun returns the range of the longest sorted run of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.LongestSortedRun. It panics if slice does not implement the
+// compare function.
+func LongestSortedRun(slice interface{}) (start, end int) {
+	return compareableSlice(reflect.ValueOf(slice)).LongestSortedRun(slice)
+}
+
+// LIS returns the indices of a longest increasing subsequence of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.LIS. It panics if slice does not implement the compare function.
+func LIS(slice interface{}) []int {
+	return compareableSlice(reflect.ValueOf(slice)).LIS(slice)
+}
+
+// FirstUnsorted returns the index of the first out of order element of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.FirstUnsorted. It panics if slice does not implement the compare
+// function.
+func FirstUnsorted(slice interface{}) int {
+	return compareableSlice(reflect.ValueOf(slice)).FirstUnsorted(slice)
+}
+
+// SearchDesc searches a decreasing Slice<T> if T implements a `func (T) Compare(T) int` for a
+// value. See Fn.SearchDesc.
+func SearchDesc(slice, value interface{}) int {
+	return compareableSlice(reflect.ValueOf(slice)).SearchDesc(slice, value)
+}
+
+// CompareSlices compares two Slice<T> lexicographically if T implements a
+// `func (T) Compare(T) int`. See Fn.CompareSlices. It panics if slice does not implement the
+// compare function.
+func CompareSlices(a, b interface{}) int {
+	return compareableSlice(reflect.ValueOf(a)).CompareSlices(a, b)
+}
+
+// EqualSlices returns whether two Slice<T> have equal elements if T implements a
+// `func (T) Compare(T) int`. See Fn.EqualSlices. It panics if slice does not implement the compare
+// function.
+func EqualSlices(a, b interface{}) bool {
+	return compareableSlice(reflect.ValueOf(a)).EqualSlices(a, b)
+}
+
+// Clamp returns a value of type T limited to the range [lo, hi] if T implements a
+// `func (T) Compare(T) int`. See Fn.Clamp. It panics if value does not implement the compare
+// function.
+func Clamp(value, lo, hi interface{}) interface{} {
+	return compareableFn(reflect.TypeOf(value)).Clamp(value, lo, hi)
+}
+
+// MinOf returns the minimal value of the given values of type T if T implements a
+// `func (T) Compare(T) int`. See Fn.MinOf. It panics if the values do not implement the compare
+// function.
+func MinOf(values ...interface{}) interface{} {
+	return compareableValues(values).MinOf(values...)
+}
+
+// MaxOf returns the maximal value of the given values of type T if T implements a
+// `func (T) Compare(T) int`. See Fn.MaxOf. It panics if the values do not implement the compare
+// function.
+func MaxOf(values ...interface{}) interface{} {
+	return compareableValues(values).MaxOf(values...)
+}
+
+// Bucketize returns the bucket index of each element of a Slice<T> if T implements a
+// `func (T) Compare(T) int`. See Fn.Bucketize. It panics if slice does not implement the compare
+// function.
+func Bucketize(slice, boundaries interface{}) []int {
+	return compareableSlice(reflect.ValueOf(slice)).Bucketize(slice, boundaries)
+}
+
+// MinMaxParallel returns the indices of the minimal and maximal values of a Slice<T> if T implements
+// a `func (T) Compare(T) int`, using the given number of workers. See Fn.MinMaxParallel. It panics if
+// slice does not implement the compare function.
+func MinMaxParallel(slice interface{}, workers int) (min, max int) {
+	return compareableSlice(reflect.ValueOf(slice)).MinMaxParallel(slice, workers)
+}
+
+// SelectParallel applies select-k algorithm on a Slice<T> if T implements a
+// `func (T) Compare(T) int`, using the given number of workers. See Fn.SelectParallel. It panics if
+// slice does not implement the compare function.
+func SelectParallel(slice interface{}, k, workers int) {
+	compareableSlice(reflect.ValueOf(slice)).SelectParallel(slice, k, workers)
+}
+
+// SortedIter returns an iterator over the elements of a Slice<T> in sorted order, if T implements a
+// `func (T) Compare(T) int`. See Fn.SortedIter. It panics if slice does not implement the compare
+// function.
+func SortedIter(slice interface{}) func(yield func(interface{}) bool) {
+	return compareableSlice(reflect.ValueOf(slice)).SortedIter(slice)
+}
+
 func compareableFn(tp reflect.Type) Fns {
 	f, err := fnOfComparableT(tp)
 	if err != nil {
@@ -66,43 +322,210 @@ func compareableFn(tp reflect.Type) Fns {
 	return f
 }
 
+// Return a compare function for the type of the first of the given values.
+func compareableValues(values []interface{}) Fns {
+	if len(values) == 0 {
+		panic("Expected at least one value")
+	}
+	return compareableFn(reflect.TypeOf(values[0]))
+}
+
 // Return a compare function for a given slice.
 func compareableSlice(slice reflect.Value) Fns {
-	s, err := reflectutil.NewSlice(slice)
+	s := mustSlice(slice)
+	fns, err := fnOfComparableT(s.T())
 	if err != nil {
+		if s.T().Kind() == reflect.Interface {
+			return dynamicFns(s)
+		}
 		panic(err)
 	}
-	return compareableFn(s.T())
+	return fns
 }
 
-var predefined = []Fns{
-	By(func(a, b int64) int { return int(a - b) }),
-	By(func(a, b uint64) int { return int(a - b) }),
-	By(strings.Compare),
-	By(bytes.Compare),
-	By(func(a, b bool) int {
+// dynamicFns returns comparison functions for a slice of an interface type, such as
+// []interface{}, that compare the elements by the comparison functions of their dynamic type.
+// Numbers of different types are compared by their numeric value. It panics if the slice contains
+// nil elements, or elements of types that can't be compared to each other.
+func dynamicFns(s reflectutil.Slice) Fns {
+	t, err := reflectutil.New(s.T())
+	if err != nil {
+		panic(err)
+	}
+	var (
+		fns   Fns
+		first int
+	)
+	for i := 0; i < s.Len(); i++ {
+		v := unwrapInterface(s.Index(i))
 		switch {
-		case a == b:
-			return 0
-		case a:
-			return 1
-		default:
-			return -1
+		case !v.IsValid():
+			panic(fmt.Sprintf("nil element at index %d of %v", i, s.Type()))
+		case fns == nil:
+			fns, first = compareableFn(v.Type()), i
+		case !fns.check(v.Type()) && !areNumbers(unwrapInterface(s.Index(first)).Type(), v.Type()):
+			panic(fmt.Sprintf("mixed element types of %v: %v at index %d and %v at index %d",
+				s.Type(), unwrapInterface(s.Index(first)).Type(), first, v.Type(), i))
 		}
-	}),
-	By(func(a, b time.Time) int {
-		switch {
-		case a.Equal(b):
-			return 0
-		case a.After(b):
-			return 1
-		default:
-			return -1
+	}
+	return Fns{{
+		fn: func(lhs, rhs reflect.Value) int {
+			lhs, rhs = unwrapInterface(lhs), unwrapInterface(rhs)
+			elemFns := fns
+			if elemFns == nil {
+				// An empty slice, use the type of the value it is compared to. The functions are
+				// resolved on each call, since the returned functions might be used concurrently.
+				elemFns = compareableFn(lhs.Type())
+			}
+			return elemFns.compare(lhs, rhs)
+		},
+		t:           t,
+		calibration: new(calibration),
+	}}
+}
+
+// Register adds comparison functions, of the form that is given to By, to the predefined functions.
+// The predefined functions are used by the package level functions, such as Sort, Search and Is, for
+// types that don't implement a `func (T) Compare(T) int` method. This allows using these functions
+// with types that can't implement the method, such as types of other packages. The functions that
+// were predefined or registered before take precedence. It panics if the functions are invalid.
+func Register(fns ...interface{}) {
+	f := By(fns...)
+	predefinedMu.Lock()
+	defer predefinedMu.Unlock()
+	predefined = append(predefined, f)
+	comparableCache = new(sync.Map)
+}
+
+// WithCompareMethod adds a name of a three-way comparison method, of the form
+// `func (T) <name>(T) int`, that is looked up by the package level functions, such as Sort, Search
+// and Is, in addition to `Compare`. This allows using these functions with types that follow a
+// different naming convention, for example `WithCompareMethod("CompareTo")`. The `Compare` method
+// and the names that were added before take precedence.
+func WithCompareMethod(name string) {
+	predefinedMu.Lock()
+	defer predefinedMu.Unlock()
+	for _, m := range compareMethods {
+		if m == name {
+			return
 		}
-	}),
+	}
+	compareMethods = append(compareMethods, name)
+	comparableCache = new(sync.Map)
 }
 
+// WithStringsFold sets whether the package level functions, such as Sort, Search and Is, compare
+// strings ignoring case, as the functions returned by StringsFold. By default, strings are compared
+// byte-wise. It affects all string types that don't have a comparison method, and it doesn't
+// affect []byte values.
+func WithStringsFold(fold bool) {
+	strs := natural(By(strings.Compare))
+	if fold {
+		strs = StringsFold()
+	}
+	predefinedMu.Lock()
+	defer predefinedMu.Unlock()
+	// The string functions are the first predefined functions. The list is copied, since the
+	// package level functions iterate over it without holding the lock.
+	predefined = append([]Fns{strs}, predefined[1:]...)
+	comparableCache = new(sync.Map)
+}
+
+// predefinedMu guards predefined, which is extended by Register, and compareMethods, which is
+// extended by WithCompareMethod. It also guards comparableCache, which is replaced when they are
+// extended.
+var predefinedMu sync.RWMutex
+
+// comparableCache maps types to their cached comparison functions lookups.
+var comparableCache = new(sync.Map)
+
+// compareMethods are names of three-way comparison methods, besides `Compare`.
+var compareMethods = []string{"Cmp"}
+
+// predefined are the comparison functions of types without a comparison method, starting with the
+// string functions, see WithStringsFold. It is set in init since By, which creates them, depends on
+// it for key functions.
+var predefined []Fns
+
+func init() {
+	predefined = []Fns{
+		natural(By(strings.Compare)),
+		By(bytes.Compare),
+		By(compareBool),
+		By(compareTime),
+		By(compareComplex),
+		By(compareIP),
+		By((*big.Int).Cmp),
+		By((*big.Float).Cmp),
+		By((*big.Rat).Cmp),
+		NullString(NullsFirst),
+		NullInt64(NullsFirst),
+		NullFloat64(NullsFirst),
+		NullTime(NullsFirst),
+	}
+}
+
+// compareTime is a three-way comparison of time values.
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Equal(b):
+		return 0
+	case a.After(b):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// compareIP is a three-way comparison of IP addresses, which compares their bytes in the 16-byte
+// form, such that an IPv4 address is equal to its IPv4-in-IPv6 form. Invalid addresses are less than
+// valid addresses.
+func compareIP(a, b net.IP) int {
+	return bytes.Compare(a.To16(), b.To16())
+}
+
+// fnOfComparableT returns the comparison functions of a type T for the package level functions.
+// The functions are looked up once for each type, and cached until the predefined functions or the
+// comparison method names are extended.
 func fnOfComparableT(tp reflect.Type) (Fns, error) {
+	return resolveFnOfComparableT(tp, nil)
+}
+
+// resolveFnOfComparableT returns the comparison functions of a type T, as fnOfComparableT. The
+// resolving types are the types whose functions are being looked up, and depend on the functions of
+// T, such that looking up a recursive type, as `type T []T`, fails instead of recursing forever.
+func resolveFnOfComparableT(tp reflect.Type, resolving map[reflect.Type]bool) (Fns, error) {
+	predefinedMu.RLock()
+	cache := comparableCache
+	predefinedMu.RUnlock()
+
+	if c, ok := cache.Load(tp); ok {
+		c := c.(cachedFns)
+		return c.fns, c.err
+	}
+	if resolving[tp] {
+		// Not cached, since the lookup of the outer type fails with this error.
+		return nil, fmt.Errorf("recursive type %v is not supported", tp)
+	}
+	if resolving == nil {
+		resolving = make(map[reflect.Type]bool)
+	}
+	resolving[tp] = true
+	fns, err := lookupFnOfComparableT(tp, resolving)
+	delete(resolving, tp)
+	cache.Store(tp, cachedFns{fns: fns, err: err})
+	return fns, err
+}
+
+// cachedFns is a cached result of a comparison functions lookup.
+type cachedFns struct {
+	fns Fns
+	err error
+}
+
+// lookupFnOfComparableT looks up the comparison functions of a type T, from its comparison methods
+// or the predefined functions. See resolveFnOfComparableT for the resolving types.
+func lookupFnOfComparableT(tp reflect.Type, resolving map[reflect.Type]bool) (Fns, error) {
 	ss := fmt.Sprintf("%v", tp)
 	_ = ss
 	method, ok := tp.MethodByName("Compare")
@@ -113,12 +536,127 @@ func fnOfComparableT(tp reflect.Type) (Fns, error) {
 		}
 		return Fns{fn}, nil
 	}
+	predefinedMu.RLock()
+	fns, methods := predefined, compareMethods
+	predefinedMu.RUnlock()
+
+	// Other methods with a different signature are ignored, since they might be unrelated to the
+	// order of the type, as in sort.Interface.
+	for _, name := range methods {
+		if method, ok := tp.MethodByName(name); ok {
+			if fn, err := newFn(method.Func); err == nil {
+				return Fns{fn}, nil
+			}
+		}
+	}
+	if method, ok := tp.MethodByName("Less"); ok {
+		if fn, err := newLessFn(method.Func); err == nil {
+			return Fns{fn}, nil
+		}
+	}
+	before, okBefore := tp.MethodByName("Before")
+	after, okAfter := tp.MethodByName("After")
+	equal, okEqual := tp.MethodByName("Equal")
+	if okBefore && okAfter && okEqual {
+		if fn, err := newBeforeAfterFn(before.Func, after.Func, equal.Func); err == nil {
+			return Fns{fn}, nil
+		}
+	}
 
-	for _, fn := range predefined {
+	// Prefer a predefined function of the exact type, since types of the same kind are convertible
+	// to each other, as with []byte and net.IP.
+	elem := tp
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	for _, fn := range fns {
+		if fn.T() == elem {
+			return fn, nil
+		}
+	}
+	if areNumbers(elem) {
+		return numberFns(tp)
+	}
+	for _, fn := range fns {
 		if fn.check(tp) {
 			return fn, nil
 		}
 	}
 
-	return nil, fmt.Errorf("Type %v should have a method 'Compare'", tp)
+	switch elem.Kind() {
+	case reflect.Array, reflect.Slice:
+		return lexicographicFns(elem, resolving)
+	case reflect.Map:
+		return mapFns(elem, resolving)
+	}
+
+	return nil, fmt.Errorf("Type %v should have a method 'Compare', 'Cmp', 'Less' or 'Before', 'After' and 'Equal'", tp)
+}
+
+// mapFns returns comparison functions of a map type, which compare map values as sequences of
+// key-value pairs, ordered by their keys. Pairs are compared by their key and then by their value,
+// where a prefix is less than the longer sequence. The keys and values are compared as in the
+// package level functions.
+func mapFns(tp reflect.Type, resolving map[reflect.Type]bool) (Fns, error) {
+	keyFns, err := resolveFnOfComparableT(tp.Key(), resolving)
+	if err != nil {
+		return nil, fmt.Errorf("keys of %v: %s", tp, err)
+	}
+	valueFns, err := resolveFnOfComparableT(tp.Elem(), resolving)
+	if err != nil {
+		return nil, fmt.Errorf("values of %v: %s", tp, err)
+	}
+	t, err := reflectutil.New(tp)
+	if err != nil {
+		return nil, err
+	}
+	sortedKeys := func(m reflect.Value) []reflect.Value {
+		keys := m.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keyFns.compare(keys[i], keys[j]) < 0 })
+		return keys
+	}
+	return Fns{{
+		fn: func(lhs, rhs reflect.Value) int {
+			l, r := t.Convert(lhs), t.Convert(rhs)
+			lKeys, rKeys := sortedKeys(l), sortedKeys(r)
+			for i := 0; i < len(lKeys) && i < len(rKeys); i++ {
+				if cmp := keyFns.compare(lKeys[i], rKeys[i]); cmp != 0 {
+					return cmp
+				}
+				if cmp := valueFns.compare(l.MapIndex(lKeys[i]), r.MapIndex(rKeys[i])); cmp != 0 {
+					return cmp
+				}
+			}
+			return len(lKeys) - len(rKeys)
+		},
+		t:           t,
+		calibration: new(calibration),
+	}}, nil
+}
+
+// lexicographicFns returns comparison functions of an array or a slice type, which compare values by
+// their elements in order, where a prefix is less than the longer value. The elements are compared
+// as in the package level functions.
+func lexicographicFns(tp reflect.Type, resolving map[reflect.Type]bool) (Fns, error) {
+	elemFns, err := resolveFnOfComparableT(tp.Elem(), resolving)
+	if err != nil {
+		return nil, fmt.Errorf("elements of %v: %s", tp, err)
+	}
+	t, err := reflectutil.New(tp)
+	if err != nil {
+		return nil, err
+	}
+	return Fns{{
+		fn: func(lhs, rhs reflect.Value) int {
+			l, r := t.Convert(lhs), t.Convert(rhs)
+			for i := 0; i < l.Len() && i < r.Len(); i++ {
+				if cmp := elemFns.compare(l.Index(i), r.Index(i)); cmp != 0 {
+					return cmp
+				}
+			}
+			return l.Len() - r.Len()
+		},
+		t:           t,
+		calibration: new(calibration),
+	}}, nil
 }
@@ -1,10 +1,20 @@
 package order
 
 import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/posener/order/internal/reflectutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPredefinedTypes(t *testing.T) {
@@ -33,6 +43,384 @@ func TestPredefinedTypes(t *testing.T) {
 	assert.True(t, Is(1*time.Nanosecond).Greater(0*time.Nanosecond))
 	assert.True(t, Is(1*time.Nanosecond).Equal(1*time.Nanosecond))
 	assert.True(t, Is(1*time.Nanosecond).Less(2*time.Nanosecond))
+
+	assert.True(t, Is(1.5).Greater(0.5))
+	assert.True(t, Is(1.5).Equal(1.5))
+	assert.True(t, Is(float32(1.5)).Less(float32(2)))
+	assert.True(t, Is(math.NaN()).Greater(math.Inf(1)))
+	floats := []float64{2, math.NaN(), 1}
+	Sort(floats)
+	assert.Equal(t, []float64{1, 2}, floats[:2])
+
+	// Complex numbers are compared by magnitude and then by phase.
+	assert.True(t, Is(2+0i).Greater(1i))
+	assert.True(t, Is(1i).Equal(1i))
+	assert.True(t, Is(1+0i).Less(1i))
+	assert.True(t, Is(-1i).Less(1+0i))
+	assert.True(t, Is(complex64(1)).Less(complex64(-1)))
+
+	// IPv4 addresses are equal to their IPv4-in-IPv6 form.
+	ip := net.ParseIP("10.0.0.2")
+	assert.True(t, Is(ip).Greater(net.IPv4(10, 0, 0, 1)))
+	assert.True(t, Is(ip).Equal(ip.To4()))
+	assert.True(t, Is(ip.To4()).Equal(net.ParseIP("::ffff:10.0.0.2")))
+	assert.True(t, Is(ip).Less(net.ParseIP("2001:db8::1")))
+	assert.True(t, Is(net.IP(nil)).Less(ip))
+	ips := []net.IP{net.ParseIP("10.0.0.10"), net.ParseIP("10.0.0.9").To4(), net.ParseIP("::1")}
+	Sort(ips)
+	assert.Equal(t, []string{"::1", "10.0.0.9", "10.0.0.10"}, []string{ips[0].String(), ips[1].String(), ips[2].String()})
+
+	assert.True(t, Is(big.NewInt(2)).Greater(big.NewInt(1)))
+	assert.True(t, Is(big.NewFloat(1.5)).Equal(big.NewFloat(1.5)))
+	assert.True(t, Is(big.NewRat(1, 3)).Less(big.NewRat(1, 2)))
+	bigs := []*big.Int{big.NewInt(10), new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(-1)}
+	Sort(bigs)
+	assert.Equal(t, []string{"-1", "10", "1267650600228229401496703205376"},
+		[]string{bigs[0].String(), bigs[1].String(), bigs[2].String()})
+
+	// A byte slice is still compared by its bytes.
+	assert.True(t, Is([]byte{1, 2, 3, 4}).Greater([]byte{1, 2, 3}))
+}
+
+// registered is a type that is registered in TestRegister.
+type registered struct{ id, version int }
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { Is(registered{}) })
+
+	Register(
+		func(a, b registered) int { return a.id - b.id },
+		func(a, b registered) int { return a.version - b.version },
+	)
+	assert.True(t, Is(registered{id: 1, version: 2}).Less(registered{id: 2, version: 1}))
+	assert.True(t, Is(registered{id: 1, version: 2}).Greater(registered{id: 1, version: 1}))
+	values := []registered{{2, 1}, {1, 2}, {1, 1}}
+	Sort(values)
+	assert.Equal(t, []registered{{1, 1}, {1, 2}, {2, 1}}, values)
+
+	assert.Panics(t, func() { Register() })
+	assert.Panics(t, func() { Register(1) })
+}
+
+func TestFnOfComparableT_cache(t *testing.T) {
+	t.Parallel()
+
+	tp := reflect.TypeOf(cmpType{})
+	fns1, err := fnOfComparableT(tp)
+	require.NoError(t, err)
+	fns2, err := fnOfComparableT(tp)
+	require.NoError(t, err)
+	assert.True(t, &fns1[0] == &fns2[0], "expected cached functions")
+
+	_, err = fnOfComparableT(reflect.TypeOf(func() {}))
+	assert.Error(t, err)
+	_, err = fnOfComparableT(reflect.TypeOf(func() {}))
+	assert.Error(t, err)
+}
+
+// cmpType implements a `Cmp` method.
+type cmpType struct{ v int }
+
+func (c cmpType) Cmp(other cmpType) int { return c.v - other.v }
+
+// unrelatedCmp implements a `Cmp` method which does not define its order.
+type unrelatedCmp struct{}
+
+func (unrelatedCmp) Cmp(other string) bool { return false }
+
+func TestCmpMethod(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Is(cmpType{1}).Less(cmpType{2}))
+	assert.True(t, Is(cmpType{1}).Equal(cmpType{1}))
+	values := []cmpType{{3}, {1}, {2}}
+	Sort(values)
+	assert.Equal(t, []cmpType{{1}, {2}, {3}}, values)
+
+	assert.Panics(t, func() { Is(unrelatedCmp{}) })
+}
+
+// version implements `Before`, `After` and `Equal` methods.
+type version struct{ major, minor int }
+
+func (v version) Before(other version) bool {
+	return v.major < other.major || (v.major == other.major && v.minor < other.minor)
+}
+
+func (v version) After(other version) bool { return other.Before(v) }
+
+func (v version) Equal(other version) bool { return v == other }
+
+// partialBeforeAfter implements only `Before` and `After` methods.
+type partialBeforeAfter struct{}
+
+func (partialBeforeAfter) Before(partialBeforeAfter) bool { return false }
+
+func (partialBeforeAfter) After(partialBeforeAfter) bool { return false }
+
+func TestBeforeAfterEqualMethods(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Is(version{1, 2}).Less(version{2, 0}))
+	assert.True(t, Is(version{1, 2}).Greater(version{1, 1}))
+	assert.True(t, Is(version{1, 2}).Equal(version{1, 2}))
+	values := []version{{2, 0}, {1, 10}, {1, 2}}
+	Sort(values)
+	assert.Equal(t, []version{{1, 2}, {1, 10}, {2, 0}}, values)
+
+	assert.Panics(t, func() { Is(partialBeforeAfter{}) })
+}
+
+func TestArrays(t *testing.T) {
+	t.Parallel()
+
+	hashes := [][4]byte{{1, 2, 3, 4}, {1, 0, 0, 0}, {0, 9, 9, 9}}
+	Sort(hashes)
+	assert.Equal(t, [][4]byte{{0, 9, 9, 9}, {1, 0, 0, 0}, {1, 2, 3, 4}}, hashes)
+	assert.Equal(t, 1, Search(hashes, [4]byte{1, 0, 0, 0}))
+
+	points := []*[2]float64{{1, 2}, {1, 1}, {0, 5}}
+	Sort(points)
+	assert.Equal(t, []*[2]float64{{0, 5}, {1, 1}, {1, 2}}, points)
+
+	// Elements are compared by their own order.
+	versions := [][2]version{{{1, 1}, {2, 0}}, {{1, 0}, {3, 0}}}
+	Sort(versions)
+	assert.Equal(t, [][2]version{{{1, 0}, {3, 0}}, {{1, 1}, {2, 0}}}, versions)
+
+	assert.True(t, Is([2]int{1, 2}).Less([2]int{1, 3}))
+	assert.Panics(t, func() { Is([2]struct{}{}) })
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	paths := [][]string{{"a", "b"}, {"a"}, {"b"}, {}, {"a", "a", "z"}}
+	Sort(paths)
+	assert.Equal(t, [][]string{{}, {"a"}, {"a", "a", "z"}, {"a", "b"}, {"b"}}, paths)
+	assert.Equal(t, 3, Search(paths, []string{"a", "b"}))
+
+	keys := [][]int{{2}, nil, {1, 2}}
+	Sort(keys)
+	assert.Equal(t, [][]int{nil, {1, 2}, {2}}, keys)
+
+	nested := [][][]int{{{1}, {2}}, {{1}, {1, 5}}}
+	Sort(nested)
+	assert.Equal(t, [][][]int{{{1}, {1, 5}}, {{1}, {2}}}, nested)
+
+	assert.True(t, Is([]int{1}).Less([]int{1, 0}))
+	assert.Panics(t, func() { Is([]struct{}{}) })
+}
+
+func TestMaps(t *testing.T) {
+	t.Parallel()
+
+	labels := []map[string]string{
+		{"env": "prod", "app": "web"},
+		{"app": "web"},
+		{"app": "db", "env": "prod"},
+		{"app": "web", "env": "dev"},
+		{},
+	}
+	Sort(labels)
+	assert.Equal(t, []map[string]string{
+		{},
+		{"app": "db", "env": "prod"},
+		{"app": "web"},
+		{"app": "web", "env": "dev"},
+		{"app": "web", "env": "prod"},
+	}, labels)
+
+	// Keys are compared by their order, and not as strings.
+	assert.True(t, Is(map[int]bool{2: true}).Less(map[int]bool{10: true}))
+	assert.True(t, Is(map[int]bool{1: false}).Less(map[int]bool{1: true}))
+	assert.True(t, Is(map[int]int(nil)).Equal(map[int]int{}))
+	assert.Panics(t, func() { Is(map[struct{}]int{}) })
+	assert.Panics(t, func() { Is(map[int]struct{}{}) })
+}
+
+func TestInterfaceSlices(t *testing.T) {
+	t.Parallel()
+
+	values := []interface{}{3, 1, 2}
+	Sort(values)
+	assert.Equal(t, []interface{}{1, 2, 3}, values)
+	assert.Equal(t, 1, Search(values, 2))
+	assert.True(t, IsSorted(values))
+
+	// Elements of convertible types.
+	values = []interface{}{int8(3), 1, int16(2)}
+	Sort(values)
+	assert.Equal(t, []interface{}{1, int16(2), int8(3)}, values)
+
+	versions := []interface{}{version{2, 0}, version{1, 0}}
+	Sort(versions)
+	assert.Equal(t, []interface{}{version{1, 0}, version{2, 0}}, versions)
+
+	// Slices of other interface types.
+	stringers := []fmt.Stringer{net.IP{2, 0, 0, 0}, net.IP{1, 0, 0, 0}}
+	Sort(stringers)
+	assert.Equal(t, []fmt.Stringer{net.IP{1, 0, 0, 0}, net.IP{2, 0, 0, 0}}, stringers)
+
+	// An empty slice.
+	Sort([]interface{}{})
+	assert.Equal(t, []interface{}{1}, Insert([]interface{}{}, 1))
+
+	assert.PanicsWithValue(t,
+		"mixed element types of []interface {}: int at index 0 and string at index 1",
+		func() { Sort([]interface{}{1, "a"}) })
+	assert.PanicsWithValue(t,
+		"nil element at index 1 of []interface {}",
+		func() { Sort([]interface{}{1, nil}) })
+}
+
+func TestInterfaceSlices_emptyConcurrent(t *testing.T) {
+	t.Parallel()
+
+	s, err := reflectutil.NewSlice(reflect.ValueOf([]interface{}{}))
+	require.NoError(t, err)
+	fns := dynamicFns(s)
+
+	// The functions of an empty slice are resolved by the compared values on each call, such that
+	// they can be used concurrently with values of different types.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, -1, fns.compare(reflect.ValueOf(1), reflect.ValueOf(2)))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 1, fns.compare(reflect.ValueOf("b"), reflect.ValueOf("a")))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStringsAndBytes(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Is("abc").Equal([]byte("abc")))
+	assert.True(t, Is([]byte("abc")).Equal("abc"))
+	assert.True(t, Is("abc").Less([]byte("abd")))
+	assert.True(t, Is([]byte("b")).Greater("a"))
+
+	values := []string{"c", "a", "b"}
+	Sort(values)
+	assert.Equal(t, 1, Search(values, []byte("b")))
+	assert.True(t, By(bytes.Compare).IsSorted(values))
+}
+
+func TestRecursiveTypes(t *testing.T) {
+	t.Parallel()
+
+	type list []list
+	type tree map[string]tree
+	type ptrs []*ptrs
+
+	assert.PanicsWithError(t,
+		"elements of order.list: recursive type order.list is not supported",
+		func() { Sort([]list{{}, {{}}}) })
+	assert.PanicsWithError(t,
+		"values of order.tree: recursive type order.tree is not supported",
+		func() { Is(tree{}) })
+	assert.Panics(t, func() { Is(ptrs{}) })
+}
+
+// compareInt32Type implements a `Compare` method that returns an int32.
+type compareInt32Type int32
+
+func (c compareInt32Type) Compare(other compareInt32Type) int32 { return int32(c - other) }
+
+func TestCompareMethod_int32(t *testing.T) {
+	t.Parallel()
+
+	values := []compareInt32Type{3, 1, 2}
+	Sort(values)
+	assert.Equal(t, []compareInt32Type{1, 2, 3}, values)
+}
+
+// compareToType implements a `CompareTo` method, which is added in TestWithCompareMethod.
+type compareToType struct{ v int }
+
+func (c compareToType) CompareTo(other compareToType) int { return c.v - other.v }
+
+func TestWithCompareMethod(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { Is(compareToType{}) })
+
+	WithCompareMethod("CompareTo")
+	WithCompareMethod("CompareTo")
+	assert.True(t, Is(compareToType{1}).Less(compareToType{2}))
+	values := []compareToType{{3}, {1}, {2}}
+	Sort(values)
+	assert.Equal(t, []compareToType{{1}, {2}, {3}}, values)
+}
+
+// TestWithStringsFold is not parallel, since it changes the order of strings in the package level
+// functions.
+func TestWithStringsFold(t *testing.T) {
+	WithStringsFold(true)
+	defer WithStringsFold(false)
+
+	assert.True(t, Is("abc").Equal("ABC"))
+	values := []string{"b", "C", "a", "B"}
+	SortStable(values)
+	assert.Equal(t, []string{"a", "b", "B", "C"}, values)
+	assert.Equal(t, 3, Search(values, "c"))
+
+	// Large slices are not sorted by the natural order fast paths.
+	large := make([]string, 1000)
+	for i := range large {
+		large[i] = string(rune('a' + i%26))
+		if i%2 == 0 {
+			large[i] = strings.ToUpper(large[i])
+		}
+	}
+	Sort(large)
+	assert.True(t, StringsFold().IsSorted(large))
+
+	WithStringsFold(false)
+	assert.False(t, Is("abc").Equal("ABC"))
+	assert.True(t, Is("B").Less("a"))
+}
+
+// lessType implements a `Less` method.
+type lessType struct{ v int }
+
+func (l lessType) Less(other lessType) bool { return l.v < other.v }
+
+// lessPtrType implements a `Less` method with a pointer receiver.
+type lessPtrType struct{ v int }
+
+func (l *lessPtrType) Less(other lessPtrType) bool { return l.v < other.v }
+
+// unrelatedLess implements a `Less` method which does not define its order.
+type unrelatedLess struct{}
+
+func (unrelatedLess) Less(i, j int) bool { return false }
+
+func TestLessMethod(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Is(lessType{1}).Less(lessType{2}))
+	assert.True(t, Is(lessType{2}).Greater(lessType{1}))
+	assert.True(t, Is(lessType{1}).Equal(lessType{1}))
+	values := []lessType{{3}, {1}, {2}}
+	Sort(values)
+	assert.Equal(t, []lessType{{1}, {2}, {3}}, values)
+	assert.Equal(t, 1, Search(values, lessType{2}))
+
+	ptrs := []*lessPtrType{{2}, {1}}
+	Sort(ptrs)
+	assert.Equal(t, 1, ptrs[0].v)
+	assert.True(t, Is(&lessPtrType{1}).Less(&lessPtrType{2}))
+
+	assert.Panics(t, func() { Is(unrelatedLess{}) })
 }
 
 type notComparable struct{}
@@ -47,11 +435,45 @@ func TestComparable_invalid(t *testing.T) {
 	fns := []func(v interface{}){
 		func(v interface{}) { Sort(v) },
 		func(v interface{}) { SortStable(v) },
+		func(v interface{}) { SortAuto(v) },
+		func(v interface{}) { Sorted(v) },
+		func(v interface{}) { SortedStable(v) },
 		func(v interface{}) { Search(v, 1) },
+		func(v interface{}) { SearchDesc(v, 1) },
 		func(v interface{}) { IsSorted(v) },
 		func(v interface{}) { IsStrictSorted(v) },
+		func(v interface{}) { IsSortedWithin(v, 1) },
 		func(v interface{}) { MinMax(v) },
 		func(v interface{}) { Select(v, 0) },
+		func(v interface{}) { EqualFrequencyBuckets(v, 2) },
+		func(v interface{}) { Heap(v) },
+		func(v interface{}) { IsHeap(v) },
+		func(v interface{}) { Argsort(v) },
+		func(v interface{}) { SortTogether(v) },
+		func(v interface{}) { Ranks(v, TiesAverage) },
+		func(v interface{}) { Rank(v, 1) },
+		func(v interface{}) { Inversions(v) },
+		func(v interface{}) { LongestSortedRun(v) },
+		func(v interface{}) { LIS(v) },
+		func(v interface{}) { FirstUnsorted(v) },
+		func(v interface{}) { CompareSlices(v, v) },
+		func(v interface{}) { EqualSlices(v, v) },
+		func(v interface{}) { Bucketize(v, []int{}) },
+		func(v interface{}) { MinMaxParallel(v, 0) },
+		func(v interface{}) { SelectParallel(v, 0, 0) },
+		func(v interface{}) { SortedIter(v) },
+		func(v interface{}) { SymmetricDifference(v, v) },
+		func(v interface{}) { Diff(v, v) },
+		func(v interface{}) { Join(v, v, func(i, j int) {}) },
+		func(v interface{}) { Compact(v) },
+		func(v interface{}) { Runs(v) },
+		func(v interface{}) { Boundaries(v) },
+		func(v interface{}) { Insert(v, 1) },
+		func(v interface{}) { Remove(v, 1) },
+		func(v interface{}) { RemoveLast(v, 1) },
+		func(v interface{}) { RemoveAll(v, 1) },
+		func(v interface{}) { Deltas(v) },
+		func(v interface{}) { MaxIncreasingStreak(v) },
 	}
 
 	for _, fn := range fns {
@@ -1,7 +1,10 @@
 package order
 
 import (
+	"fmt"
+	"math"
 	"reflect"
+	"time"
 )
 
 // Condition allows comparing a given lhs value.
@@ -15,6 +18,21 @@ func (fns Fns) Is(lhs interface{}) Condition {
 	return Condition{Fns: fns, lhs: fns.mustValue(reflect.ValueOf(lhs))}
 }
 
+// Equal tests if a is equal to b. It is a shorthand for `fns.Is(a).Equal(b)`.
+func (fns Fns) Equal(a, b interface{}) bool {
+	return fns.Is(a).Equal(b)
+}
+
+// Less tests if a is less than b. It is a shorthand for `fns.Is(a).Less(b)`.
+func (fns Fns) Less(a, b interface{}) bool {
+	return fns.Is(a).Less(b)
+}
+
+// Greater tests if a is greater than b. It is a shorthand for `fns.Is(a).Greater(b)`.
+func (fns Fns) Greater(a, b interface{}) bool {
+	return fns.Is(a).Greater(b)
+}
+
 // Equal tests if the compared lhs object is equal to the given rhs object.
 func (c Condition) Equal(rhs interface{}) bool {
 	return c.compare(c.lhs, reflect.ValueOf(rhs)) == 0
@@ -44,3 +62,115 @@ func (c Condition) Less(rhs interface{}) bool {
 func (c Condition) LessEqual(rhs interface{}) bool {
 	return c.compare(c.lhs, reflect.ValueOf(rhs)) <= 0
 }
+
+// EqualWithin tests if the compared lhs object is equal to the given rhs object, or close to it
+// according to the tolerance. The difference between the values is computed from the values
+// themselves, and not by the comparison functions. The values should be numbers, or time.Time values
+// where the difference is a time.Duration. It panics for values of other types.
+func (c Condition) EqualWithin(rhs interface{}, tolerance Tolerance) bool {
+	r := reflect.ValueOf(rhs)
+	if c.compare(c.lhs, r) == 0 {
+		return true
+	}
+	diff, magnitude := difference(c.lhs, r)
+	return diff <= tolerance.Abs || diff <= tolerance.Rel*magnitude
+}
+
+// Tolerance defines when two values are close enough to be considered equal by
+// Condition.EqualWithin. Values are close enough if their difference is within the absolute or
+// within the relative tolerance.
+type Tolerance struct {
+	// Abs is the maximal absolute difference between equal values. For time.Time values it is in
+	// nanoseconds, for example `Tolerance{Abs: float64(time.Second)}`.
+	Abs float64
+	// Rel is the maximal difference between equal numbers, relative to the greater magnitude of the
+	// two. For example, 0.01 allows a difference of 1%. It does not apply to time.Time values.
+	Rel float64
+}
+
+// difference returns the absolute difference between two numbers, and the greater magnitude of
+// them. For two time.Time values, it returns the duration between them, and a zero magnitude.
+func difference(a, b reflect.Value) (diff, magnitude float64) {
+	a, b = indirect(unwrapInterface(a)), indirect(unwrapInterface(b))
+	if a.Type() == timeType && b.Type() == timeType {
+		d := a.Interface().(time.Time).Sub(b.Interface().(time.Time))
+		if d < 0 {
+			d = -d
+		}
+		return float64(d), 0
+	}
+	fa, fb := floatOf(a), floatOf(b)
+	return math.Abs(fa - fb), math.Max(math.Abs(fa), math.Abs(fb))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// floatOf returns the value of a number as a float. It panics if the value is not a number.
+func floatOf(v reflect.Value) float64 {
+	switch numberKindOf(v.Kind()) {
+	case intNumber:
+		return float64(v.Int())
+	case uintNumber:
+		return float64(v.Uint())
+	case floatNumber:
+		return v.Float()
+	default:
+		panic(fmt.Sprintf("EqualWithin is not supported for type %v", v.Type()))
+	}
+}
+
+// Between tests if the lhs object is between the given lo and hi objects, where bounds defines
+// whether lo and hi are included in the range.
+func (c Condition) Between(lo, hi interface{}, bounds Bounds) bool {
+	loInclusive, hiInclusive := bounds.inclusive()
+	if cmp := c.compare(c.lhs, reflect.ValueOf(lo)); cmp < 0 || (cmp == 0 && !loInclusive) {
+		return false
+	}
+	cmp := c.compare(c.lhs, reflect.ValueOf(hi))
+	return cmp < 0 || (cmp == 0 && hiInclusive)
+}
+
+// In tests if the lhs object is equal to any of the given objects.
+func (c Condition) In(values ...interface{}) bool {
+	for _, v := range values {
+		if c.compare(c.lhs, reflect.ValueOf(v)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// NotIn tests if the lhs object is not equal to any of the given objects.
+func (c Condition) NotIn(values ...interface{}) bool {
+	return !c.In(values...)
+}
+
+// Bounds defines which of the bounds of a range of values are included in the range.
+type Bounds int
+
+const (
+	// Closed includes both bounds: [lo, hi].
+	Closed Bounds = iota
+	// Open excludes both bounds: (lo, hi).
+	Open
+	// ClosedOpen includes the lower bound and excludes the upper bound: [lo, hi).
+	ClosedOpen
+	// OpenClosed excludes the lower bound and includes the upper bound: (lo, hi].
+	OpenClosed
+)
+
+// inclusive returns whether the lower and the upper bounds are included.
+func (b Bounds) inclusive() (lo, hi bool) {
+	switch b {
+	case Closed:
+		return true, true
+	case Open:
+		return false, false
+	case ClosedOpen:
+		return true, false
+	case OpenClosed:
+		return false, true
+	default:
+		panic(fmt.Sprintf("invalid bounds: %d", b))
+	}
+}
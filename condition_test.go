@@ -1,7 +1,11 @@
 package order
 
 import (
+	"fmt"
+	"math"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +36,32 @@ func TestIs(t *testing.T) {
 	assert.True(t, Is(1).LessEqual(2))
 }
 
+func TestFns_predicates(t *testing.T) {
+	t.Parallel()
+
+	fns := By(func(a, b int) int { return a - b })
+
+	assert.True(t, fns.Equal(1, 1))
+	assert.False(t, fns.Equal(1, 2))
+	assert.True(t, fns.Less(1, 2))
+	assert.False(t, fns.Less(1, 1))
+	assert.True(t, fns.Greater(2, 1))
+	assert.False(t, fns.Greater(1, 1))
+
+	assert.True(t, fns.Reversed().Less(2, 1))
+
+	assert.Panics(t, func() { fns.Equal(true, 1) })
+	assert.Panics(t, func() { fns.Less(1, true) })
+
+	// Values of mixed numeric types, as with Is.
+	numbers := compareableFn(reflect.TypeOf(0))
+	assert.True(t, numbers.Less(3, 3.5))
+	assert.Equal(t, numbers.Is(3).Less(3.5), numbers.Less(3, 3.5))
+	assert.True(t, numbers.Greater(uint8(200), -1))
+	assert.True(t, numbers.Equal(2, 2.0))
+	assert.True(t, fns.Less(1, 2.0))
+}
+
 func TestIs_invalidArgType(t *testing.T) {
 	t.Parallel()
 
@@ -48,3 +78,99 @@ func TestIs_invalidArgType(t *testing.T) {
 	assert.Panics(t, func() { cIs.Less(true) })
 	assert.Panics(t, func() { cIs.LessEqual(true) })
 }
+
+func TestIs_between(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value  int
+		bounds Bounds
+		want   bool
+	}{
+		{value: 0, bounds: Closed, want: false},
+		{value: 1, bounds: Closed, want: true},
+		{value: 2, bounds: Closed, want: true},
+		{value: 3, bounds: Closed, want: true},
+		{value: 4, bounds: Closed, want: false},
+		{value: 1, bounds: Open, want: false},
+		{value: 2, bounds: Open, want: true},
+		{value: 3, bounds: Open, want: false},
+		{value: 1, bounds: ClosedOpen, want: true},
+		{value: 3, bounds: ClosedOpen, want: false},
+		{value: 1, bounds: OpenClosed, want: false},
+		{value: 3, bounds: OpenClosed, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d/%d", tt.value, tt.bounds), func(t *testing.T) {
+			assert.Equal(t, tt.want, Is(tt.value).Between(1, 3, tt.bounds))
+		})
+	}
+
+	// An empty range.
+	assert.False(t, Is(2).Between(3, 1, Closed))
+	assert.False(t, Is(1).Between(1, 1, ClosedOpen))
+	assert.True(t, Is(1).Between(1, 1, Closed))
+
+	assert.Panics(t, func() { Is(1).Between(1, 3, Bounds(-1)) })
+}
+
+func TestIs_in(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Is(2).In(1, 2, 3))
+	assert.False(t, Is(4).In(1, 2, 3))
+	assert.False(t, Is(1).In())
+
+	assert.False(t, Is(2).NotIn(1, 2, 3))
+	assert.True(t, Is(4).NotIn(1, 2, 3))
+	assert.True(t, Is(1).NotIn())
+
+	// Equality is according to the comparison functions.
+	mod10 := By(func(a, b int) int { return a%10 - b%10 })
+	assert.True(t, mod10.Is(12).In(1, 2))
+}
+
+func TestIs_equalWithin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		lhs, rhs  interface{}
+		tolerance Tolerance
+		want      bool
+	}{
+		{name: "equal", lhs: 1.0, rhs: 1.0, want: true},
+		{name: "zero tolerance", lhs: 1.0, rhs: 1.1, want: false},
+		{name: "absolute", lhs: 1.0, rhs: 1.1, tolerance: Tolerance{Abs: 0.2}, want: true},
+		{name: "absolute exceeded", lhs: 1.0, rhs: 1.3, tolerance: Tolerance{Abs: 0.2}, want: false},
+		{name: "relative", lhs: 1000.0, rhs: 1009.0, tolerance: Tolerance{Rel: 0.01}, want: true},
+		{name: "relative exceeded", lhs: 1000.0, rhs: 1011.0, tolerance: Tolerance{Rel: 0.01}, want: false},
+		{name: "relative near zero", lhs: 0.0, rhs: 1e-9, tolerance: Tolerance{Rel: 0.01}, want: false},
+		{name: "absolute or relative", lhs: 0.0, rhs: 1e-9, tolerance: Tolerance{Abs: 1e-6, Rel: 0.01}, want: true},
+		{name: "ints", lhs: 10, rhs: 12, tolerance: Tolerance{Abs: 2}, want: true},
+		{name: "mixed numbers", lhs: 10, rhs: 10.5, tolerance: Tolerance{Abs: 1}, want: true},
+		{name: "NaN", lhs: 1.0, rhs: math.NaN(), tolerance: Tolerance{Abs: math.Inf(1)}, want: false},
+		{name: "NaNs", lhs: math.NaN(), rhs: math.NaN(), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Is(tt.lhs).EqualWithin(tt.rhs, tt.tolerance))
+			assert.Equal(t, tt.want, Is(tt.rhs).EqualWithin(tt.lhs, tt.tolerance))
+		})
+	}
+}
+
+func TestIs_equalWithinTime(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := Tolerance{Abs: float64(time.Second)}
+	assert.True(t, Is(t0).EqualWithin(t0.Add(time.Second), second))
+	assert.True(t, Is(t0).EqualWithin(t0.Add(-time.Millisecond), second))
+	assert.False(t, Is(t0).EqualWithin(t0.Add(time.Second+1), second))
+	assert.True(t, Is(&t0).EqualWithin(t0.Add(time.Millisecond), second))
+
+	assert.Panics(t, func() { Is("a").EqualWithin("b", Tolerance{Abs: 1}) })
+}
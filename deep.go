@@ -0,0 +1,182 @@
+package order
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// Deep returns comparison functions that give a deterministic total order to values of any type,
+// by comparing them recursively:
+//
+// - Values of types that have a comparison method or a predefined comparison function, such as
+// time.Time, are compared as in the package level functions, such as Sort.
+//
+// - Basic values are compared by their value. Floating point NaN values are greater than other
+// values, and complex numbers are compared by their magnitude and then their phase.
+//
+// - Structs are compared field by field, and arrays and slices are compared lexicographically.
+//
+// - Maps are compared as lexicographic sequences of key-value pairs, ordered by their keys.
+//
+// - Pointers and interfaces are compared by the values they point to, where nil is less than any
+// other value. Channels, functions and unsafe pointers are compared by their address.
+//
+// - Values of different types are compared by the names of their types.
+//
+// This is useful for canonicalization and for a stable test output. The compared values should not
+// be cyclic. The comparison functions of types are cached by the returned functions.
+func Deep() Fns {
+	t, err := reflectutil.New(reflect.TypeOf((*interface{})(nil)).Elem())
+	if err != nil {
+		panic(err)
+	}
+	d := &deep{}
+	return Fns{{fn: d.compare, t: t, calibration: new(calibration)}}
+}
+
+// deep implements Deep.
+type deep struct {
+	// fns caches the comparison functions of types, where nil means that the type has no
+	// comparison functions.
+	fns sync.Map
+}
+
+func (d *deep) compare(a, b reflect.Value) int {
+	a, b = unwrapInterface(a), unwrapInterface(b)
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	}
+	if ta, tb := a.Type(), b.Type(); ta != tb {
+		if cmp := strings.Compare(ta.String(), tb.String()); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(ta.PkgPath(), tb.PkgPath())
+	}
+
+	if a.Kind() == reflect.Ptr {
+		switch {
+		case a.Pointer() == b.Pointer():
+			return 0
+		case a.IsNil():
+			return -1
+		case b.IsNil():
+			return 1
+		}
+	}
+	if a.CanInterface() && b.CanInterface() {
+		if fns := d.fnsOf(a.Type()); fns != nil {
+			return fns.compare(a, b)
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return compareBool(a.Bool(), b.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareInt64(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareUint64(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareFloat(a.Float(), b.Float(), 1)
+	case reflect.Complex64, reflect.Complex128:
+		return compareComplex(a.Complex(), b.Complex())
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Ptr:
+		return d.compare(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if cmp := d.compare(a.Field(i), b.Field(i)); cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			if cmp := d.compare(a.Index(i), b.Index(i)); cmp != 0 {
+				return cmp
+			}
+		}
+		return compareInt64(int64(a.Len()), int64(b.Len()))
+	case reflect.Map:
+		aKeys, bKeys := d.sortedKeys(a), d.sortedKeys(b)
+		for i := 0; i < len(aKeys) && i < len(bKeys); i++ {
+			if cmp := d.compare(aKeys[i], bKeys[i]); cmp != 0 {
+				return cmp
+			}
+			if cmp := d.compare(a.MapIndex(aKeys[i]), b.MapIndex(bKeys[i])); cmp != 0 {
+				return cmp
+			}
+		}
+		return compareInt64(int64(len(aKeys)), int64(len(bKeys)))
+	default:
+		// Channels, functions and unsafe pointers.
+		return compareUint64(uint64(a.Pointer()), uint64(b.Pointer()))
+	}
+}
+
+// fnsOf returns the comparison functions of a type that has methods or is a struct, or nil if there
+// are no such functions. Other types are compared by their value.
+func (d *deep) fnsOf(tp reflect.Type) Fns {
+	if tp.NumMethod() == 0 && tp.Kind() != reflect.Struct {
+		return nil
+	}
+	if fns, ok := d.fns.Load(tp); ok {
+		return fns.(Fns)
+	}
+	fns, err := fnOfComparableT(tp)
+	if err != nil {
+		fns = nil
+	}
+	d.fns.Store(tp, fns)
+	return fns
+}
+
+// sortedKeys returns the keys of a map value, in order.
+func (d *deep) sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return d.compare(keys[i], keys[j]) < 0 })
+	return keys
+}
+
+// unwrapInterface returns the value that an interface value holds, or an invalid value for a nil
+// interface.
+func unwrapInterface(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+// compareBool is a three-way comparison of bool values, where false is less than true.
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// compareUint64 is a three-way comparison of uint64 values.
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,88 @@
+package order
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type deepInner struct {
+	Names []string
+	Tags  map[string]int
+}
+
+type deepValue struct {
+	ID    int
+	inner *deepInner
+	When  time.Time
+}
+
+func TestDeep(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	one, otherOne, two := 1, 1, 2
+
+	tests := []struct {
+		name     string
+		lo, hi   interface{}
+		wantLess bool
+	}{
+		{name: "nil interface", lo: []interface{}{nil}, hi: []interface{}{0}, wantLess: true},
+		{name: "bool", lo: false, hi: true, wantLess: true},
+		{name: "int", lo: -1, hi: 1, wantLess: true},
+		{name: "uint", lo: uint8(1), hi: uint8(200), wantLess: true},
+		{name: "float NaN", lo: 1.0, hi: math.NaN(), wantLess: true},
+		{name: "string", lo: "a", hi: "b", wantLess: true},
+		{name: "different types", lo: 1, hi: "1", wantLess: true},
+		{name: "nil pointer", lo: (*int)(nil), hi: &one, wantLess: true},
+		{name: "pointers", lo: &one, hi: &two, wantLess: true},
+		{name: "equal pointees", lo: &one, hi: &otherOne, wantLess: false},
+		{name: "array", lo: [2]int{1, 2}, hi: [2]int{1, 3}, wantLess: true},
+		{name: "slice prefix", lo: []int{1}, hi: []int{1, 0}, wantLess: true},
+		{name: "nested slice", lo: [][]int{{1, 2}}, hi: [][]int{{2}}, wantLess: true},
+		{name: "map by keys", lo: map[string]int{"a": 9}, hi: map[string]int{"b": 0}, wantLess: true},
+		{name: "map by values", lo: map[string]int{"a": 1, "b": 1}, hi: map[string]int{"a": 1, "b": 2}, wantLess: true},
+		{name: "interface slice", lo: []interface{}{1, "a"}, hi: []interface{}{1, "b"}, wantLess: true},
+		{name: "time", lo: t0, hi: t0.Add(time.Second), wantLess: true},
+		{
+			name:     "struct unexported fields",
+			lo:       deepValue{ID: 1, inner: &deepInner{Tags: map[string]int{"a": 1}}},
+			hi:       deepValue{ID: 1, inner: &deepInner{Tags: map[string]int{"a": 2}}},
+			wantLess: true,
+		},
+		{
+			name:     "struct time field",
+			lo:       deepValue{ID: 1, inner: &deepInner{}, When: t0},
+			hi:       deepValue{ID: 1, inner: &deepInner{}, When: t0.Add(time.Second)},
+			wantLess: true,
+		},
+	}
+
+	fns := Deep()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantLess, fns.Is(tt.lo).Less(tt.hi))
+			assert.False(t, fns.Is(tt.hi).Less(tt.lo))
+			assert.True(t, fns.Is(tt.lo).Equal(tt.lo))
+		})
+	}
+}
+
+func TestDeep_sort(t *testing.T) {
+	t.Parallel()
+
+	got := []deepInner{
+		{Names: []string{"b"}},
+		{Names: []string{"a"}, Tags: map[string]int{"x": 1}},
+		{Names: []string{"a"}},
+	}
+	Deep().Sort(got)
+	assert.Equal(t, []deepInner{
+		{Names: []string{"a"}},
+		{Names: []string{"a"}, Tags: map[string]int{"x": 1}},
+		{Names: []string{"b"}},
+	}, got)
+}
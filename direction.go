@@ -0,0 +1,88 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Direction is the direction in which a slice is sorted, as returned by Fns.Direction.
+type Direction int
+
+const (
+	// Unordered means that the slice is sorted in neither direction.
+	Unordered Direction = iota
+	// Constant means that all the elements of the slice are equal. This includes slices with less
+	// than two elements.
+	Constant
+	// Increasing means that the slice is sorted in a non-decreasing order, and has equal adjacent
+	// elements.
+	Increasing
+	// StrictlyIncreasing means that the slice is sorted in a strictly increasing order.
+	StrictlyIncreasing
+	// Decreasing means that the slice is sorted in a non-increasing order, and has equal adjacent
+	// elements.
+	Decreasing
+	// StrictlyDecreasing means that the slice is sorted in a strictly decreasing order.
+	StrictlyDecreasing
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Unordered:
+		return "unordered"
+	case Constant:
+		return "constant"
+	case Increasing:
+		return "increasing"
+	case StrictlyIncreasing:
+		return "strictly increasing"
+	case Decreasing:
+		return "decreasing"
+	case StrictlyDecreasing:
+		return "strictly decreasing"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+}
+
+// IsSorted returns whether the direction is of a slice that is sorted in a non-decreasing order.
+func (d Direction) IsSorted() bool {
+	return d == Constant || d == Increasing || d == StrictlyIncreasing
+}
+
+// IsReversed returns whether the direction is of a slice that is sorted in a non-increasing order.
+func (d Direction) IsReversed() bool {
+	return d == Constant || d == Decreasing || d == StrictlyDecreasing
+}
+
+// Direction returns the direction in which the slice is sorted according to the comparison
+// function, with a single pass over the slice. A slice that is Constant is sorted in both
+// directions.
+func (fns Fns) Direction(slice interface{}) Direction {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	var less, greater, equal bool
+	for i := 1; i < s.Len() && !(less && greater); i++ {
+		switch cmp := fns.compare(s.Index(i-1), s.Index(i)); {
+		case cmp < 0:
+			less = true
+		case cmp > 0:
+			greater = true
+		default:
+			equal = true
+		}
+	}
+	switch {
+	case less && greater:
+		return Unordered
+	case less && equal:
+		return Increasing
+	case less:
+		return StrictlyIncreasing
+	case greater && equal:
+		return Decreasing
+	case greater:
+		return StrictlyDecreasing
+	default:
+		return Constant
+	}
+}
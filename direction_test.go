@@ -0,0 +1,44 @@
+package order
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  Direction
+	}{
+		{slice: []int{}, want: Constant},
+		{slice: []int{1}, want: Constant},
+		{slice: []int{1, 1, 1}, want: Constant},
+		{slice: []int{1, 2, 2, 3}, want: Increasing},
+		{slice: []int{1, 2, 3}, want: StrictlyIncreasing},
+		{slice: []int{3, 3, 2, 1}, want: Decreasing},
+		{slice: []int{3, 2, 1}, want: StrictlyDecreasing},
+		{slice: []int{1, 3, 2}, want: Unordered},
+		{slice: []int{2, 2, 1, 3}, want: Unordered},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			got := intFn.Direction(tt.slice)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, IsSorted(tt.slice), got.IsSorted())
+			assert.Equal(t, intFn.Reversed().IsSorted(tt.slice), got.IsReversed())
+		})
+	}
+}
+
+func TestDirection_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "unordered", Unordered.String())
+	assert.Equal(t, "strictly decreasing", StrictlyDecreasing.String())
+	assert.Equal(t, "Direction(10)", Direction(10).String())
+}
@@ -0,0 +1,93 @@
+package order
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Estimate is an estimation of the cost of an operation.
+type Estimate struct {
+	// Comparisons is the expected number of comparisons.
+	Comparisons int
+	// Duration is the approximated duration of the operation. It is computed from the expected
+	// number of comparisons and a calibrated duration of a single comparison. It is zero if the
+	// calibration could not be done.
+	Duration time.Duration
+}
+
+// EstimateSortCost returns an estimation of the cost of sorting a slice of n elements with
+// Fns.Sort. The expected number of comparisons is n*log2(n).
+//
+// The duration of a single comparison is calibrated once for each comparison function, by comparing
+// zero values of T, and the calibrated durations of all the functions are summed. Since the
+// following functions are only applied to equal values, the duration is an upper bound. Comparison
+// functions which take time that depends on the compared values might not be estimated accurately.
+// If a comparison function panics for zero values of T, its duration can't be calibrated and the
+// returned Duration is zero.
+//
+// Slices of built-in types that are sorted by their natural order, such as []int and []string, are
+// sorted without reflection, and large slices of integer and string kinds are sorted with radix
+// sort, such that sorting them is usually much faster than the estimated Duration.
+func (fns Fns) EstimateSortCost(n int) Estimate {
+	if n <= 1 {
+		return Estimate{}
+	}
+	comparisons := int(math.Ceil(float64(n) * math.Log2(float64(n))))
+	return Estimate{
+		Comparisons: comparisons,
+		Duration:    time.Duration(comparisons) * fns.comparisonDuration(),
+	}
+}
+
+// calibrationCount is the number of comparisons that are used to calibrate a comparison duration.
+const calibrationCount = 1000
+
+// calibration holds the calibrated duration of a single comparison of a function. It is created
+// with the function, and shared by its copies, such that the function is calibrated once.
+type calibration struct {
+	once sync.Once
+	// d is the calibrated duration, or a negative value if the calibration failed.
+	d time.Duration
+}
+
+// comparisonDuration returns the calibrated duration of a single comparison by all the functions.
+// It returns zero if any of the functions could not be calibrated.
+func (fns Fns) comparisonDuration() time.Duration {
+	var total time.Duration
+	for _, fn := range fns {
+		d := fn.comparisonDuration()
+		if d < 0 {
+			return 0
+		}
+		total += d
+	}
+	return total
+}
+
+// comparisonDuration returns the calibrated duration of a single comparison of the function. It
+// returns a negative value if the function could not be calibrated.
+func (fn Fn) comparisonDuration() time.Duration {
+	if fn.calibration == nil {
+		return -1
+	}
+	fn.calibration.once.Do(func() { fn.calibration.d = fn.calibrate() })
+	return fn.calibration.d
+}
+
+// calibrate measures the duration of a single comparison of zero values. It returns a negative
+// value if the function panics for zero values.
+func (fn Fn) calibrate() (d time.Duration) {
+	defer func() {
+		if r := recover(); r != nil {
+			d = -1
+		}
+	}()
+	zero := reflect.Zero(fn.T())
+	start := time.Now()
+	for i := 0; i < calibrationCount; i++ {
+		fn.fn(zero, zero)
+	}
+	return time.Since(start) / calibrationCount
+}
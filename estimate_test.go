@@ -0,0 +1,37 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateSortCost(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Estimate{}, intFn.EstimateSortCost(0))
+	assert.Equal(t, Estimate{}, intFn.EstimateSortCost(1))
+
+	got := intFn.EstimateSortCost(1024)
+	assert.Equal(t, 1024*10, got.Comparisons)
+	assert.True(t, got.Duration > 0)
+
+	// A bigger slice should cost more.
+	assert.True(t, intFn.EstimateSortCost(2048).Comparisons > got.Comparisons)
+
+	// The functions are calibrated once, and their copies share the calibration.
+	assert.Equal(t, got, intFn.EstimateSortCost(1024))
+	assert.Equal(t, got, intFn.Reversed().EstimateSortCost(1024))
+}
+
+func TestEstimateSortCost_calibrationPanics(t *testing.T) {
+	t.Parallel()
+
+	type box struct{ v *int }
+	// The comparison function panics for zero values.
+	fns := By(func(a, b box) int { return *a.v - *b.v })
+
+	got := fns.EstimateSortCost(1024)
+	assert.Equal(t, 1024*10, got.Comparisons)
+	assert.Equal(t, 0, int(got.Duration))
+}
@@ -0,0 +1,118 @@
+package order
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// natural marks the given functions as the natural order of their type, such that the package
+// can use fast paths, that access the elements without reflection, for slices of built-in types.
+func natural(fns Fns) Fns {
+	for i := range fns {
+		fns[i].natural = true
+	}
+	return fns
+}
+
+// isNatural returns whether the functions are the natural order of their type.
+func (fns Fns) isNatural() bool {
+	return len(fns) == 1 && fns[0].natural
+}
+
+// sortNatural sorts the given slice without reflection if it is of a built-in type and the
+// functions are its natural order. Large slices of integer and string kinds are sorted with radix
+// sort. It returns false if the slice should be sorted with reflection.
+func (fns Fns) sortNatural(slice interface{}, stable bool) bool {
+	if fns.sortRadix(slice) {
+		return true
+	}
+	cmp, ok := fns.naturalCompare(slice)
+	if !ok {
+		return false
+	}
+	// Equal ints and strings are identical, such that sorting them is always stable.
+	switch s := slice.(type) {
+	case []int:
+		sort.Ints(s)
+		return true
+	case []string:
+		sort.Strings(s)
+		return true
+	}
+	less := func(i, j int) bool { return cmp(i, j) < 0 }
+	if stable {
+		sort.SliceStable(slice, less)
+	} else {
+		sort.Slice(slice, less)
+	}
+	return true
+}
+
+// naturalCompare returns a three-way comparison of elements of the given slice, that does not use
+// reflection, if the slice is of a built-in type and the functions are its natural order. It
+// returns false otherwise.
+func (fns Fns) naturalCompare(slice interface{}) (func(i, j int) int, bool) {
+	if !fns.isNatural() {
+		return nil, false
+	}
+	var cmp func(i, j int) int
+	switch s := slice.(type) {
+	case []int:
+		cmp = func(i, j int) int { return compareInt64(int64(s[i]), int64(s[j])) }
+	case []int64:
+		cmp = func(i, j int) int { return compareInt64(s[i], s[j]) }
+	case []float64:
+		cmp = func(i, j int) int { return compareFloat(s[i], s[j], 1) }
+	case []string:
+		cmp = func(i, j int) int { return strings.Compare(s[i], s[j]) }
+	default:
+		return nil, false
+	}
+	if !fns.check(reflect.TypeOf(slice).Elem()) {
+		return nil, false
+	}
+	return cmp, true
+}
+
+// naturalCompareValue returns a three-way comparison of elements of the given slice with the given
+// value, that does not use reflection, if the slice and the value are of the same built-in type and
+// the functions are its natural order. It returns false otherwise.
+func (fns Fns) naturalCompareValue(slice, value interface{}) (func(i int) int, bool) {
+	if !fns.isNatural() {
+		return nil, false
+	}
+	var cmp func(i int) int
+	switch s := slice.(type) {
+	case []int:
+		v, ok := value.(int)
+		if !ok {
+			return nil, false
+		}
+		cmp = func(i int) int { return compareInt64(int64(s[i]), int64(v)) }
+	case []int64:
+		v, ok := value.(int64)
+		if !ok {
+			return nil, false
+		}
+		cmp = func(i int) int { return compareInt64(s[i], v) }
+	case []float64:
+		v, ok := value.(float64)
+		if !ok {
+			return nil, false
+		}
+		cmp = func(i int) int { return compareFloat(s[i], v, 1) }
+	case []string:
+		v, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		cmp = func(i int) int { return strings.Compare(s[i], v) }
+	default:
+		return nil, false
+	}
+	if !fns.check(reflect.TypeOf(slice).Elem()) {
+		return nil, false
+	}
+	return cmp, true
+}
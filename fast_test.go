@@ -0,0 +1,106 @@
+package order
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaturalFastPaths(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+
+	tests := []struct {
+		name   string
+		slice  interface{}
+		want   interface{}
+		value  interface{}
+		search int
+		min    int
+		max    int
+	}{
+		{
+			name:   "ints",
+			slice:  []int{3, 1, 2, 1},
+			want:   []int{1, 1, 2, 3},
+			value:  2,
+			search: 2,
+			min:    1,
+			max:    0,
+		},
+		{
+			name:   "int64s",
+			slice:  []int64{3, 1, 2, math.MinInt64},
+			want:   []int64{math.MinInt64, 1, 2, 3},
+			value:  int64(3),
+			search: 3,
+			min:    3,
+			max:    0,
+		},
+		{
+			name:   "float64s",
+			slice:  []float64{2.5, nan, -1, 2.5},
+			want:   []float64{-1, 2.5, 2.5, nan},
+			value:  -1.0,
+			search: 0,
+			min:    2,
+			max:    1,
+		},
+		{
+			name:   "strings",
+			slice:  []string{"b", "c", "a"},
+			want:   []string{"a", "b", "c"},
+			value:  "c",
+			search: 2,
+			min:    2,
+			max:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			min, max := MinMax(tt.slice)
+			assert.Equal(t, tt.min, min)
+			assert.Equal(t, tt.max, max)
+
+			sorted := Sorted(tt.slice)
+			assertEqualFloats(t, tt.want, sorted)
+			assertEqualFloats(t, tt.want, SortedStable(tt.slice))
+			assert.Equal(t, tt.search, Search(sorted, tt.value))
+		})
+	}
+}
+
+func TestNaturalFastPaths_fallback(t *testing.T) {
+	t.Parallel()
+
+	// Values of other types are compared by the reflection path.
+	assert.Equal(t, 1, Search([]float64{1, 2, 3}, 2))
+	assert.Equal(t, -1, Search([]float64{1, 2, 3}, int64(4)))
+
+	// Named types and custom functions don't use the fast paths.
+	ints := namedInts{3, 1, 2}
+	Sort(ints)
+	assert.Equal(t, namedInts{1, 2, 3}, ints)
+
+	desc := []int{1, 3, 2}
+	intFn.Reversed().Sort(desc)
+	assert.Equal(t, []int{3, 2, 1}, desc)
+
+	// Natural functions of a different type panic on a wrong slice type.
+	assert.Panics(t, func() { compareableFn(reflect.TypeOf("")).Sort([]int{2, 1}) })
+}
+
+// assertEqualFloats asserts that two slices are equal, where NaN values are equal to each other.
+func assertEqualFloats(t *testing.T, want, got interface{}) {
+	t.Helper()
+	assert.True(t, EqualSlices(want, got), "want: %v, got: %v", want, got)
+}
+
+type namedInts []int
@@ -0,0 +1,75 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// ByFields returns comparison functions of a struct type, which compare values by the given
+// exported fields, in order. A field name with a leading '-' is compared in descending order. Each
+// field is compared as in the package level functions, such as Sort, such that its type should have
+// a `Compare` method or a predefined comparison function. Fields of embedded structs can be given
+// by their promoted names. This enables choosing the order at runtime, for example:
+//
+//	order.ByFields(reflect.TypeOf(person{}), "Name", "-Age").Sort(persons)
+//
+// The given type may also be a pointer to a struct. It panics if the type is not a struct, or if a
+// field does not exist, is not exported, or its type has no order.
+func ByFields(tp reflect.Type, fields ...string) Fns {
+	if len(fields) == 0 {
+		panic("Expected at least one field")
+	}
+	for tp.Kind() == reflect.Ptr {
+		tp = tp.Elem()
+	}
+	if tp.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected a struct type, got: %v", tp))
+	}
+	t, err := reflectutil.New(tp)
+	if err != nil {
+		panic(err)
+	}
+	fns := make(Fns, 0, len(fields))
+	for _, name := range fields {
+		fn, err := newFieldFn(t, name)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid field %q: %s", name, err))
+		}
+		fns = append(fns, fn)
+	}
+	return fns
+}
+
+// newFieldFn returns a compare function of struct values of type t by a field with the given name,
+// which is descending if the name has a leading '-'.
+func newFieldFn(t reflectutil.T, name string) (Fn, error) {
+	descending := strings.HasPrefix(name, "-")
+	name = strings.TrimPrefix(name, "-")
+	field, ok := t.FieldByName(name)
+	if !ok {
+		return Fn{}, fmt.Errorf("no such field in %v", t)
+	}
+	if field.PkgPath != "" {
+		return Fn{}, fmt.Errorf("field is not exported")
+	}
+	fieldFns, err := fnOfComparableT(field.Type)
+	if err != nil {
+		return Fn{}, err
+	}
+	sign := 1
+	if descending {
+		sign = -1
+	}
+	return Fn{
+		fn: func(lhs, rhs reflect.Value) int {
+			l := t.Convert(lhs).FieldByIndex(field.Index)
+			r := t.Convert(rhs).FieldByIndex(field.Index)
+			return sign * fieldFns.compare(l, r)
+		},
+		t:           t,
+		calibration: new(calibration),
+	}, nil
+}
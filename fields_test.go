@@ -0,0 +1,86 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldsBase struct {
+	Created time.Time
+}
+
+type fieldsPerson struct {
+	fieldsBase
+	Name string
+	Age  int
+	id   int
+}
+
+func TestByFields(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	a1 := fieldsPerson{Name: "a", Age: 1, fieldsBase: fieldsBase{t0.Add(time.Hour)}}
+	a2 := fieldsPerson{Name: "a", Age: 2, fieldsBase: fieldsBase{t0}}
+	b1 := fieldsPerson{Name: "b", Age: 1, fieldsBase: fieldsBase{t0}}
+
+	tests := []struct {
+		fields []string
+		want   []fieldsPerson
+	}{
+		{fields: []string{"Name", "Age"}, want: []fieldsPerson{a1, a2, b1}},
+		{fields: []string{"Name", "-Age"}, want: []fieldsPerson{a2, a1, b1}},
+		{fields: []string{"-Name"}, want: []fieldsPerson{b1, a1, a2}},
+		{fields: []string{"Age", "-Name"}, want: []fieldsPerson{b1, a1, a2}},
+		{fields: []string{"Created", "Name"}, want: []fieldsPerson{a2, b1, a1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.fields), func(t *testing.T) {
+			got := []fieldsPerson{b1, a1, a2}
+			ByFields(reflect.TypeOf(fieldsPerson{}), tt.fields...).SortStable(got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestByFields_pointers(t *testing.T) {
+	t.Parallel()
+
+	fns := ByFields(reflect.TypeOf(&fieldsPerson{}), "-Age")
+	got := []*fieldsPerson{{Age: 1}, {Age: 3}, {Age: 2}}
+	fns.Sort(got)
+	assert.Equal(t, []*fieldsPerson{{Age: 3}, {Age: 2}, {Age: 1}}, got)
+
+	assert.True(t, fns.Is(fieldsPerson{Age: 3}).Less(&fieldsPerson{Age: 2}))
+}
+
+func TestByFields_invalid(t *testing.T) {
+	t.Parallel()
+
+	type noOrder struct {
+		Fn func()
+	}
+
+	tests := []struct {
+		name   string
+		tp     reflect.Type
+		fields []string
+	}{
+		{name: "no fields", tp: reflect.TypeOf(fieldsPerson{})},
+		{name: "not a struct", tp: reflect.TypeOf(1), fields: []string{"Name"}},
+		{name: "unknown field", tp: reflect.TypeOf(fieldsPerson{}), fields: []string{"Unknown"}},
+		{name: "unexported field", tp: reflect.TypeOf(fieldsPerson{}), fields: []string{"id"}},
+		{name: "field without order", tp: reflect.TypeOf(noOrder{}), fields: []string{"Fn"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Panics(t, func() { ByFields(tt.tp, tt.fields...) })
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package order
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// NaNLast orders floating point numbers in an increasing order, where NaN values are greater than
+// all other values and equal to each other. This gives a total order, such that slices that contain
+// NaN values are sorted deterministically. It can be used for float64 and float32 values.
+var NaNLast = By(func(a, b float64) int { return compareFloat(a, b, 1) })
+
+// NaNFirst orders floating point numbers in an increasing order, where NaN values are less than all
+// other values and equal to each other. See NaNLast.
+var NaNFirst = By(func(a, b float64) int { return compareFloat(a, b, -1) })
+
+// compareFloat is a three-way comparison of floating point numbers, where a NaN value is compared
+// to other values as nan, and is equal to another NaN value.
+func compareFloat(a, b float64, nan int) int {
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return nan
+	case bNaN:
+		return -nan
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareComplex is a three-way comparison of complex numbers, which compares their magnitude, and
+// then their phase, in the range [-Pi, Pi]. NaN magnitudes and phases are greater than other values.
+func compareComplex(a, b complex128) int {
+	if cmp := compareFloat(cmplx.Abs(a), cmplx.Abs(b), 1); cmp != 0 {
+		return cmp
+	}
+	return compareFloat(cmplx.Phase(a), cmplx.Phase(b), 1)
+}
@@ -0,0 +1,38 @@
+package order
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaN(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	slice := []float64{2, nan, -inf, 1, nan, inf}
+	NaNLast.Sort(slice)
+	assert.Equal(t, []float64{-inf, 1, 2, inf}, slice[:4])
+	assert.True(t, math.IsNaN(slice[4]))
+	assert.True(t, math.IsNaN(slice[5]))
+	assert.True(t, NaNLast.IsSorted(slice))
+
+	NaNFirst.Sort(slice)
+	assert.True(t, math.IsNaN(slice[0]))
+	assert.True(t, math.IsNaN(slice[1]))
+	assert.Equal(t, []float64{-inf, 1, 2, inf}, slice[2:])
+	assert.True(t, NaNFirst.IsSorted(slice))
+
+	assert.True(t, NaNLast.Is(nan).Equal(nan))
+	assert.True(t, NaNLast.Is(inf).Less(nan))
+	assert.True(t, NaNFirst.Is(nan).Less(-inf))
+	assert.True(t, NaNLast.Is(math.Copysign(0, -1)).Equal(0.0))
+
+	// Float32 values.
+	slice32 := []float32{float32(nan), 1, -1}
+	NaNLast.Sort(slice32)
+	assert.Equal(t, []float32{-1, 1}, slice32[:2])
+}
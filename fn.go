@@ -3,6 +3,7 @@ package order
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/posener/order/internal/reflectutil"
 )
@@ -14,48 +15,311 @@ type Fns []Fn
 type Fn struct {
 	// fns are the 3-way functions, of the form func(T, T) int.
 	fn func(lhs, rhs reflect.Value) int
+	// bind optionally returns fn specialized for values of the given types, such that the
+	// conversion of the values to T is resolved once instead of on every comparison.
+	bind binder
+	// natural indicates that fn is the natural order of a built-in type. See natural.
+	natural bool
 	// t stores the type of the function (T).
 	t reflectutil.T
+	// calibration holds the calibrated duration of fn. See Fns.EstimateSortCost.
+	calibration *calibration
 }
 
+// binder returns a compare function for values of the given types.
+type binder func(lhs, rhs reflect.Type) func(lhs, rhs reflect.Value) int
+
 // newFn converts a given function value to the a compare function. It also checks that the
 // function `f` is of the right form (func(T, T) int) and that T is of the given type t. If the
-// given type t is nil, it will be set to the type of the first argument of f.
+// given type t is nil, it will be set to the type of the first argument of f. The returned value may
+// be of any signed integer kind.
 func newFn(f reflect.Value) (Fn, error) {
-	if f.Kind() != reflect.Func {
-		return Fn{}, fmt.Errorf("expected function")
+	t1, t2, err := fnArgs(f)
+	if err != nil {
+		return Fn{}, err
 	}
 	tp := f.Type()
-	if in := tp.NumIn(); in != 2 {
-		return Fn{}, fmt.Errorf("expected function with 2 arguments, got: %d", in)
+	if out := tp.NumOut(); out != 1 {
+		return Fn{}, fmt.Errorf("expected function with a single return value, got: %d", out)
+	}
+	if out := tp.Out(0); !isSignedInt(out.Kind()) {
+		return Fn{}, fmt.Errorf("expected function with signed integer return value, got: %v", out)
+	}
+	compare, ok := directFn(f)
+	if !ok {
+		compare = func(lhs, rhs reflect.Value) int {
+			// Normalize the result to its sign, since it might not fit in an int.
+			switch c := f.Call([]reflect.Value{lhs, rhs})[0].Int(); {
+			case c < 0:
+				return -1
+			case c > 0:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return Fn{
+		fn:          func(lhs, rhs reflect.Value) int { return compare(t1.Convert(lhs), t2.Convert(rhs)) },
+		bind:        bindConverters(t1, t2, compare),
+		t:           t1,
+		calibration: new(calibration),
+	}, nil
+}
+
+// directFn returns a compare function that calls the given function directly, without
+// reflect.Value.Call, which allocates on every call, if the function is of a common built-in type.
+// The values given to the returned function should already be converted to the function arguments
+// types.
+func directFn(f reflect.Value) (func(lhs, rhs reflect.Value) int, bool) {
+	if !f.CanInterface() {
+		return nil, false
+	}
+	switch fn := f.Interface().(type) {
+	case func(a, b int) int:
+		return func(lhs, rhs reflect.Value) int { return sign(fn(int(lhs.Int()), int(rhs.Int()))) }, true
+	case func(a, b int64) int:
+		return func(lhs, rhs reflect.Value) int { return sign(fn(lhs.Int(), rhs.Int())) }, true
+	case func(a, b float64) int:
+		return func(lhs, rhs reflect.Value) int { return sign(fn(lhs.Float(), rhs.Float())) }, true
+	case func(a, b string) int:
+		return func(lhs, rhs reflect.Value) int { return sign(fn(lhs.String(), rhs.String())) }, true
+	case func(a, b []byte) int:
+		return func(lhs, rhs reflect.Value) int { return sign(fn(lhs.Bytes(), rhs.Bytes())) }, true
+	case func(a, b bool) int:
+		return func(lhs, rhs reflect.Value) int { return sign(fn(lhs.Bool(), rhs.Bool())) }, true
+	case func(a, b time.Time) int:
+		return func(lhs, rhs reflect.Value) int {
+			return sign(fn(lhs.Interface().(time.Time), rhs.Interface().(time.Time)))
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// directPredicate returns a predicate that calls the given function directly, without
+// reflect.Value.Call, if the function is of a common built-in type. See directFn.
+func directPredicate(f reflect.Value) (func(lhs, rhs reflect.Value) bool, bool) {
+	if !f.CanInterface() {
+		return nil, false
+	}
+	switch fn := f.Interface().(type) {
+	case func(a, b int) bool:
+		return func(lhs, rhs reflect.Value) bool { return fn(int(lhs.Int()), int(rhs.Int())) }, true
+	case func(a, b int64) bool:
+		return func(lhs, rhs reflect.Value) bool { return fn(lhs.Int(), rhs.Int()) }, true
+	case func(a, b float64) bool:
+		return func(lhs, rhs reflect.Value) bool { return fn(lhs.Float(), rhs.Float()) }, true
+	case func(a, b string) bool:
+		return func(lhs, rhs reflect.Value) bool { return fn(lhs.String(), rhs.String()) }, true
+	default:
+		return nil, false
+	}
+}
+
+// bindConverters returns a binder of a function that compares values of types t1 and t2. The
+// returned compare function converts the values with converters that are resolved for the bound
+// types.
+func bindConverters(t1, t2 reflectutil.T, compare func(lhs, rhs reflect.Value) int) binder {
+	return func(lhsT, rhsT reflect.Type) func(lhs, rhs reflect.Value) int {
+		lhsConv, lhsOK := t1.Converter(lhsT)
+		rhsConv, rhsOK := t2.Converter(rhsT)
+		if !lhsOK || !rhsOK {
+			return func(lhs, rhs reflect.Value) int { return compare(t1.Convert(lhs), t2.Convert(rhs)) }
+		}
+		return func(lhs, rhs reflect.Value) int { return compare(lhsConv(lhs), rhsConv(rhs)) }
+	}
+}
+
+// newByFn converts a function value given to By to a compare function. It accepts three-way
+// comparison functions, of the form func(T, T) int, less functions, of the form func(T, T) bool,
+// and key functions, of the form func(T) K.
+func newByFn(f reflect.Value) (Fn, error) {
+	if f.Kind() == reflect.Func {
+		tp := f.Type()
+		if tp.NumIn() == 1 {
+			return newKeyFn(f)
+		}
+		if tp.NumOut() == 1 && tp.Out(0).Kind() == reflect.Bool {
+			return newLessFn(f)
+		}
 	}
-	// If t is not set yet, set it to the first argument of the function.
-	t1, err := reflectutil.New(tp.In(0))
+	return newFn(f)
+}
+
+// newKeyFn converts a given function value of the form func(T) K, which extracts a key from a value,
+// to a compare function. The keys are compared as in the package level functions, such that K
+// should have a `Compare` method or a predefined comparison function.
+func newKeyFn(f reflect.Value) (Fn, error) {
+	tp := f.Type()
+	if tp.IsVariadic() {
+		return Fn{}, fmt.Errorf("expected non-variadic function")
+	}
+	t, err := reflectutil.New(tp.In(0))
 	if err != nil {
 		return Fn{}, err
 	}
-	t2, err := reflectutil.New(tp.In(1))
+	if out := tp.NumOut(); out != 1 {
+		return Fn{}, fmt.Errorf("expected key function with a single return value, got: %d", out)
+	}
+	keyFns, err := fnOfComparableT(tp.Out(0))
 	if err != nil {
-		return Fn{}, err
+		return Fn{}, fmt.Errorf("invalid key type: %s", err)
+	}
+	key := func(v reflect.Value) reflect.Value {
+		return f.Call([]reflect.Value{v})[0]
 	}
+	// The keys are always of the key type, bind the key functions to it once.
+	keyFns = keyFns.bind(tp.Out(0), tp.Out(0))
+	compare := func(lhs, rhs reflect.Value) int { return keyFns.compare(key(lhs), key(rhs)) }
+	return Fn{
+		fn:          func(lhs, rhs reflect.Value) int { return compare(t.Convert(lhs), t.Convert(rhs)) },
+		bind:        bindConverters(t, t, compare),
+		t:           t,
+		calibration: new(calibration),
+	}, nil
+}
 
-	if t1.Type != t2.Type {
-		return Fn{}, fmt.Errorf("expected same types, got: %v, %v", t1, t2)
+// isSignedInt returns whether the given kind is of a signed integer.
+func isSignedInt(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
 	}
-	if out := tp.NumOut(); out != 1 {
-		return Fn{}, fmt.Errorf("expected function with a single return value, got: %d", out)
+}
+
+// newLessFn converts a given function value of the form func(T, T) bool, which returns whether lhs
+// is less than rhs, to a compare function. Two values are equal if none of them is less than the
+// other.
+func newLessFn(f reflect.Value) (Fn, error) {
+	less, t, err := newPredicate(f)
+	if err != nil {
+		return Fn{}, err
 	}
-	if out := tp.Out(0); out.Kind() != reflect.Int {
-		return Fn{}, fmt.Errorf("expected function with int return value, got: %v", out)
+	return Fn{
+		fn: func(lhs, rhs reflect.Value) int {
+			switch {
+			case less(lhs, rhs):
+				return -1
+			case less(rhs, lhs):
+				return 1
+			default:
+				return 0
+			}
+		},
+		t:           t,
+		calibration: new(calibration),
+	}, nil
+}
+
+// newBeforeAfterFn converts function values of the form func(T, T) bool, which return whether lhs
+// is before, after, or equal to rhs, to a compare function.
+func newBeforeAfterFn(before, after, equal reflect.Value) (Fn, error) {
+	var preds [3]func(lhs, rhs reflect.Value) bool
+	var t reflectutil.T
+	for i, f := range []reflect.Value{before, after, equal} {
+		pred, predT, err := newPredicate(f)
+		if err != nil {
+			return Fn{}, err
+		}
+		if i > 0 && predT.Type != t.Type {
+			return Fn{}, fmt.Errorf("expected same types, got: %v, %v", t, predT)
+		}
+		preds[i], t = pred, predT
 	}
+	isBefore, isAfter, isEqual := preds[0], preds[1], preds[2]
 	return Fn{
 		fn: func(lhs, rhs reflect.Value) int {
-			return f.Call([]reflect.Value{t1.Convert(lhs), t2.Convert(rhs)})[0].Interface().(int)
+			switch {
+			case isEqual(lhs, rhs):
+				return 0
+			case isBefore(lhs, rhs):
+				return -1
+			case isAfter(lhs, rhs):
+				return 1
+			default:
+				return 0
+			}
 		},
-		t: t1,
+		t:           t,
+		calibration: new(calibration),
 	}, nil
 }
 
+// newPredicate converts a given function value of the form func(T, T) bool to a function over
+// values of type T.
+func newPredicate(f reflect.Value) (func(lhs, rhs reflect.Value) bool, reflectutil.T, error) {
+	t1, t2, err := fnArgs(f)
+	if err != nil {
+		return nil, t1, err
+	}
+	tp := f.Type()
+	if out := tp.NumOut(); out != 1 {
+		return nil, t1, fmt.Errorf("expected function with a single return value, got: %d", out)
+	}
+	if out := tp.Out(0); out.Kind() != reflect.Bool {
+		return nil, t1, fmt.Errorf("expected function with bool return value, got: %v", out)
+	}
+	pred, ok := directPredicate(f)
+	if !ok {
+		pred = func(lhs, rhs reflect.Value) bool {
+			return f.Call([]reflect.Value{lhs, rhs})[0].Bool()
+		}
+	}
+	return func(lhs, rhs reflect.Value) bool {
+		return pred(t1.Convert(lhs), t2.Convert(rhs))
+	}, t1, nil
+}
+
+// fnArgs checks that the given function value has two arguments of the same type T, and returns
+// their types.
+func fnArgs(f reflect.Value) (t1, t2 reflectutil.T, err error) {
+	if f.Kind() != reflect.Func {
+		return t1, t2, fmt.Errorf("expected function")
+	}
+	tp := f.Type()
+	if in := tp.NumIn(); in != 2 {
+		return t1, t2, fmt.Errorf("expected function with 2 arguments, got: %d", in)
+	}
+	if tp.IsVariadic() {
+		return t1, t2, fmt.Errorf("expected non-variadic function")
+	}
+	t1, err = reflectutil.New(tp.In(0))
+	if err != nil {
+		return t1, t2, err
+	}
+	t2, err = reflectutil.New(tp.In(1))
+	if err != nil {
+		return t1, t2, err
+	}
+	if t1.Type != t2.Type {
+		return t1, t2, fmt.Errorf("expected same types, got: %v, %v", t1, t2)
+	}
+	return t1, t2, nil
+}
+
+// bind returns the functions specialized for comparing values of type lhs with values of type rhs.
+// It should be called once before comparing many values of the same types, such that the values
+// conversions are not resolved on each comparison. Functions that can't be specialized are kept as
+// they are.
+func (fns Fns) bind(lhs, rhs reflect.Type) Fns {
+	bound := make(Fns, len(fns))
+	for i, fn := range fns {
+		bound[i] = fn
+		if fn.bind != nil {
+			bound[i].fn = fn.bind(lhs, rhs)
+		}
+	}
+	return bound
+}
+
+// bindSlice returns the functions specialized for comparing elements of the given slice.
+func (fns Fns) bindSlice(s reflectutil.Slice) Fns {
+	return fns.bind(s.T(), s.T())
+}
+
 // compare compares two values using the comparsion functions. It starts from the first comparison
 // function and continues as long as the returned value is 0.
 func (fns Fns) compare(lhs, rhs reflect.Value) int {
@@ -67,11 +331,12 @@ func (fns Fns) compare(lhs, rhs reflect.Value) int {
 	return 0
 }
 
-// append a function to the function list, and check that its type agrees with the list type.
+// append a function to the function list, and check that its type agrees with the list type: values
+// of the list type T should be convertible to the type of the function.
 func (fns Fns) append(fn Fn) (Fns, error) {
 	if len(fns) != 0 {
-		if !fns.check(fn.T()) {
-			return nil, fmt.Errorf("all functions should have the same type, got: %v, %v", fns.T(), fn.T())
+		if !fn.t.Check(fns.T()) {
+			return nil, fmt.Errorf("all functions should have convertible types, got: %v, %v", fns.T(), fn.T())
 		}
 	}
 	return append(fns, fn), nil
@@ -87,27 +352,60 @@ func (fn Fn) T() reflect.Type {
 	return fn.t.Type
 }
 
+// check returns whether values of the given type can be compared by the functions. Natural
+// functions of a numeric type compare values of all numeric types, see numberFns.
 func (fns Fns) check(tp reflect.Type) bool {
+	if fns.isNatural() && areNumbers(fns.T(), indirectType(tp)) {
+		return true
+	}
 	return fns[0].t.Check(tp)
-
 }
 
-// mustValue panics if the given value is not of type T.
+// mustValue panics if the given value is not of type T, or if it can't be converted to the types of
+// the functions.
 func (fns Fns) mustValue(v reflect.Value) reflect.Value {
 	if tp := v.Type(); !fns.check(tp) {
 		panic(fmt.Sprintf("bad value type: expected: %v, got: %v", fns.T(), tp))
 	}
+	for _, fn := range fns.inexact(v.Type()) {
+		if !fn.t.CheckValue(v) {
+			panic(fmt.Sprintf("value %v can't be converted to %v without losing precision", v, fn.T()))
+		}
+	}
 	return v
 }
 
-// mustSlice panics if a given slice value is not a slice value or does not match T.
+// mustSlice panics if a given slice value is not a slice value or does not match T. It also panics
+// if any of the elements can't be converted to the types of the functions, such that the elements
+// are checked before the slice is modified and not by the comparisons.
 func (fns Fns) mustSlice(slice reflect.Value) reflectutil.Slice {
-	s, err := reflectutil.NewSlice(slice)
-	if err != nil {
-		panic(err)
-	}
-	if tp := s.T(); !fns.check(tp) {
+	s := mustSlice(slice)
+	tp := s.T()
+	if !fns.check(tp) {
 		panic(fmt.Sprintf("wrong slice type: expected []%v, got: %v", fns.T(), tp))
 	}
+	for _, fn := range fns.inexact(tp) {
+		for i := 0; i < s.Len(); i++ {
+			if v := s.Index(i); !fn.t.CheckValue(v) {
+				panic(fmt.Sprintf("value %v at index %d can't be converted to %v without losing precision",
+					v, i, fn.T()))
+			}
+		}
+	}
 	return s
 }
+
+// inexact returns the functions that can't convert all values of the given type to their type. See
+// reflectutil.T.Inexact. Natural functions of numeric types compare all numeric values exactly.
+func (fns Fns) inexact(tp reflect.Type) []Fn {
+	if fns.isNatural() {
+		return nil
+	}
+	var inexact []Fn
+	for _, fn := range fns {
+		if fn.t.Inexact(tp) {
+			inexact = append(inexact, fn)
+		}
+	}
+	return inexact
+}
@@ -0,0 +1,88 @@
+package order
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// ByHash returns a comparison function that orders values by a seeded hash of a key that is
+// extracted from them. This gives a pseudo-random order which is deterministic: the same seed and
+// keys result in the same order across runs and processes, and different seeds result in
+// independent orders. It can be used for stable shuffling, bucketing or load spreading. Values
+// with equal hashes are ordered by their key, such that only values with equal keys are equal.
+//
+// keyFn should be of the form func(T) K, where K is a string or a []byte. It panics if keyFn is
+// not of this form.
+func ByHash(seed uint64, keyFn interface{}) Fns {
+	f := reflect.ValueOf(keyFn)
+	t, err := hashKeyT(f)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid key function: %s", err))
+	}
+	key := func(v reflect.Value) []byte {
+		k := f.Call([]reflect.Value{t.Convert(v)})[0]
+		if k.Kind() == reflect.String {
+			return []byte(k.String())
+		}
+		return k.Bytes()
+	}
+	return Fns{{
+		fn: func(lhs, rhs reflect.Value) int {
+			a, b := key(lhs), key(rhs)
+			ha, hb := hashKey(seed, a), hashKey(seed, b)
+			switch {
+			case ha < hb:
+				return -1
+			case ha > hb:
+				return 1
+			default:
+				return bytes.Compare(a, b)
+			}
+		},
+		t:           t,
+		calibration: new(calibration),
+	}}
+}
+
+// hashKeyT checks that f is of the form func(T) K, where K is a string or a []byte, and returns T.
+func hashKeyT(f reflect.Value) (reflectutil.T, error) {
+	if f.Kind() != reflect.Func {
+		return reflectutil.T{}, fmt.Errorf("expected function")
+	}
+	tp := f.Type()
+	if in := tp.NumIn(); in != 1 || tp.IsVariadic() {
+		return reflectutil.T{}, fmt.Errorf("expected function with 1 non-variadic argument, got: %v", tp)
+	}
+	if out := tp.NumOut(); out != 1 {
+		return reflectutil.T{}, fmt.Errorf("expected function with a single return value, got: %d", out)
+	}
+	out := tp.Out(0)
+	if out.Kind() != reflect.String && (out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uint8) {
+		return reflectutil.T{}, fmt.Errorf("expected function with string or []byte return value, got: %v", out)
+	}
+	return reflectutil.New(tp.In(0))
+}
+
+// hashKey returns the 64 bit FNV-1a hash of the seed followed by the key, with a final mixing step
+// such that all the bits of the hash depend on all the bits of the input.
+func hashKey(seed uint64, key []byte) uint64 {
+	var s [8]byte
+	binary.LittleEndian.PutUint64(s[:], seed)
+	h := fnv.New64a()
+	h.Write(s[:])
+	h.Write(key)
+
+	// The splitmix64 finalizer.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
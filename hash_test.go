@@ -0,0 +1,71 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct{ id string }
+
+func userID(u user) string { return u.id }
+
+func TestByHash(t *testing.T) {
+	t.Parallel()
+
+	var users []user
+	for i := 0; i < 100; i++ {
+		users = append(users, user{id: fmt.Sprintf("user-%d", i)})
+	}
+
+	sorted := func(fns Fns, users []user) []user {
+		got := append([]user(nil), users...)
+		fns.Sort(got)
+		return got
+	}
+
+	got := sorted(ByHash(1, userID), users)
+	assert.False(t, By(func(a, b user) int { return strings.Compare(a.id, b.id) }).IsSorted(got))
+	assert.ElementsMatch(t, users, got)
+
+	// The order does not depend on the original order, and is the same for the same seed.
+	reversed := append([]user(nil), users...)
+	Reverse(reversed)
+	assert.Equal(t, got, sorted(ByHash(1, userID), reversed))
+	assert.Equal(t, got, sorted(ByHash(1, userID), users))
+
+	// Different seeds give different orders.
+	assert.NotEqual(t, got, sorted(ByHash(2, userID), users))
+
+	// Only equal keys are equal.
+	fns := ByHash(1, userID)
+	assert.True(t, fns.Is(user{id: "a"}).Equal(user{id: "a"}))
+	assert.False(t, fns.Is(user{id: "a"}).Equal(user{id: "b"}))
+
+	// Bytes keys.
+	assert.Equal(t, got, sorted(ByHash(1, func(u user) []byte { return []byte(u.id) }), users))
+}
+
+func TestByHash_stable(t *testing.T) {
+	t.Parallel()
+
+	// The hash function should not change, to keep orders stable across versions.
+	assert.Equal(t, uint64(0xa8147c24bd365456), hashKey(1, []byte("key")))
+}
+
+func TestByHash_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, keyFn := range []interface{}{
+		nil,
+		1,
+		func(a, b user) string { return "" },
+		func(u user) int { return 0 },
+		func(u user) (string, error) { return "", nil },
+		func(u ...user) string { return "" },
+	} {
+		assert.Panics(t, func() { ByHash(1, keyFn) }, "%T", keyFn)
+	}
+}
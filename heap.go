@@ -0,0 +1,135 @@
+package order
+
+import (
+	"container/heap"
+	"fmt"
+	"reflect"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// Heap returns a heap.Interface over the given slice, ordered by the comparison functions, such that
+// the container/heap functions could be used with it. The heap is a min-heap, to get a max-heap use
+// `fns.Reversed().Heap(slice)`. To use the Push and Pop methods, which change the length of the
+// slice, the given slice should be a pointer to a slice, otherwise these methods panic. Values that
+// are pushed are converted to the slice element type.
+func (fns Fns) Heap(slice interface{}) heap.Interface {
+	v := reflect.ValueOf(slice)
+	h := &sliceHeap{fns: fns, s: fns.mustSlice(v)}
+	if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Slice {
+		h.ptr = v
+	}
+	return h
+}
+
+// IsHeap returns whether the slice satisfies the min-heap property according to the comparison
+// functions: each element is not less than its parent, where the parent of element i is element
+// (i-1)/2. This is the order that is kept by the container/heap functions over `fns.Heap(slice)`.
+//
+// To check if a slice is a max-heap, it is possible to `fn.Reversed().IsHeap(slice)`.
+func (fns Fns) IsHeap(slice interface{}) bool {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	for i := 1; i < s.Len(); i++ {
+		if fns.compare(s.Index((i-1)/2), s.Index(i)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sliceHeap implements heap.Interface over a slice.
+type sliceHeap struct {
+	fns Fns
+	s   reflectutil.Slice
+	// ptr is a pointer to the slice, it is invalid if the slice was not given as a pointer.
+	ptr reflect.Value
+}
+
+func (h *sliceHeap) Len() int { return h.s.Len() }
+
+func (h *sliceHeap) Less(i, j int) bool { return h.fns.compare(h.s.Index(i), h.s.Index(j)) < 0 }
+
+func (h *sliceHeap) Swap(i, j int) { h.s.Swap(i, j) }
+
+func (h *sliceHeap) Push(x interface{}) {
+	h.mustPtr()
+	v := h.fns.mustValue(reflect.ValueOf(x))
+	v = mustConverter(h.s.T(), v.Type())(v)
+	h.set(reflect.Append(h.s.Value, v))
+}
+
+func (h *sliceHeap) Pop() interface{} {
+	h.mustPtr()
+	n := h.s.Len() - 1
+	last := h.s.Index(n)
+	x := last.Interface()
+	// Allow garbage collection of the popped value.
+	last.Set(reflect.Zero(h.s.T()))
+	h.set(h.s.Value.Slice(0, n))
+	return x
+}
+
+// set updates the slice that the heap operates on, and the slice that ptr points to.
+func (h *sliceHeap) set(s reflect.Value) {
+	h.ptr.Elem().Set(s)
+	h.s = h.fns.mustSlice(s)
+}
+
+func (h *sliceHeap) mustPtr() {
+	if !h.ptr.IsValid() {
+		panic(fmt.Sprintf("heap over %v should be given a pointer to a slice to push and pop", h.s.Type()))
+	}
+}
+
+// SortedIter returns an iterator that yields the elements of the given slice in sorted order,
+// according to the comparison functions, while leaving the slice untouched. Equal elements are
+// yielded in their original order. The iterator is backed by a heap of indices, such that the first
+// element is yielded after O(n) comparisons, and each of the following elements after O(log n)
+// comparisons, which is useful when only a few of the smallest elements are needed. The iterator
+// calls yield for each element until yield returns false, and each call to the iterator starts a
+// new iteration. The slice should not be modified during the iteration.
+//
+// The iterator is of the form of `iter.Seq[interface{}]`, which is not referenced since this module
+// supports Go versions that don't have the iter package. With Go 1.23 or later it can be used in a
+// range loop, `for v := range fns.SortedIter(slice)`, or converted to an `iter.Seq[any]`.
+func (fns Fns) SortedIter(slice interface{}) func(yield func(interface{}) bool) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	return func(yield func(interface{}) bool) {
+		h := &indexHeap{fns: fns.bindSlice(s), s: s, indices: make([]int, s.Len())}
+		for i := range h.indices {
+			h.indices[i] = i
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			if !yield(s.Index(heap.Pop(h).(int)).Interface()) {
+				return
+			}
+		}
+	}
+}
+
+// indexHeap implements heap.Interface over indices of a slice, ordered by the elements they point
+// to, and then by the indices themselves.
+type indexHeap struct {
+	fns     Fns
+	s       reflectutil.Slice
+	indices []int
+}
+
+func (h *indexHeap) Len() int { return len(h.indices) }
+
+func (h *indexHeap) Less(i, j int) bool {
+	a, b := h.indices[i], h.indices[j]
+	cmp := h.fns.compare(h.s.Index(a), h.s.Index(b))
+	return cmp < 0 || (cmp == 0 && a < b)
+}
+
+func (h *indexHeap) Swap(i, j int) { h.indices[i], h.indices[j] = h.indices[j], h.indices[i] }
+
+func (h *indexHeap) Push(x interface{}) { h.indices = append(h.indices, x.(int)) }
+
+func (h *indexHeap) Pop() interface{} {
+	i := h.indices[len(h.indices)-1]
+	h.indices = h.indices[:len(h.indices)-1]
+	return i
+}
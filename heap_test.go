@@ -0,0 +1,148 @@
+package order
+
+import (
+	"container/heap"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeap(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{5, 2, 8}
+	h := intFn.Heap(&slice)
+	heap.Init(h)
+	assert.Equal(t, 2, slice[0])
+
+	heap.Push(h, 1)
+	heap.Push(h, int8(9))
+	assert.Equal(t, 5, len(slice))
+	assert.Equal(t, 1, slice[0])
+
+	var got []interface{}
+	for h.Len() > 0 {
+		got = append(got, heap.Pop(h))
+	}
+	assert.Equal(t, []interface{}{1, 2, 5, 8, 9}, got)
+	assert.Empty(t, slice)
+}
+
+func TestHeap_reversed(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{5, 2, 8, 1}
+	h := intFn.Reversed().Heap(&slice)
+	heap.Init(h)
+	assert.Equal(t, 8, heap.Pop(h))
+	assert.Equal(t, 5, heap.Pop(h))
+}
+
+func TestHeap_notPointer(t *testing.T) {
+	t.Parallel()
+
+	// Without a pointer, the heap can still be initialized and fixed.
+	slice := []int{5, 2, 8}
+	h := intFn.Heap(slice)
+	heap.Init(h)
+	assert.Equal(t, 2, slice[0])
+	slice[0] = 10
+	heap.Fix(h, 0)
+	assert.Equal(t, 5, slice[0])
+
+	assert.Panics(t, func() { heap.Push(h, 1) })
+	assert.Panics(t, func() { heap.Pop(h) })
+}
+
+func TestHeap_invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { intFn.Heap([]string{}) })
+
+	slice := []int8{}
+	h := intFn.Heap(&slice)
+	// Can't convert int to int8.
+	assert.Panics(t, func() { heap.Push(h, 1) })
+	assert.Panics(t, func() { heap.Push(h, "1") })
+}
+
+func TestIsHeap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  bool
+	}{
+		{slice: nil, want: true},
+		{slice: []int{1}, want: true},
+		{slice: []int{1, 1, 1}, want: true},
+		{slice: []int{1, 3, 2, 4, 5, 2}, want: true},
+		{slice: []int{2, 1}, want: false},
+		{slice: []int{1, 3, 2, 4, 2}, want: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsHeap(tt.slice), tt.slice)
+	}
+
+	// Heap order is kept by the container/heap functions.
+	slice := []int{5, 2, 8, 1, 9, 3}
+	heap.Init(intFn.Heap(&slice))
+	assert.True(t, intFn.IsHeap(slice))
+	assert.True(t, intFn.Reversed().IsHeap([]int{9, 8, 5, 1, 2, 3}))
+}
+
+func TestSortedIter(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{5, 2, 4, 1, 3}
+	next := intFn.SortedIter(slice)
+
+	var got []interface{}
+	next(func(v interface{}) bool {
+		got = append(got, v)
+		return len(got) < 3
+	})
+	assert.Equal(t, []interface{}{1, 2, 3}, got)
+	assert.Equal(t, []int{5, 2, 4, 1, 3}, slice)
+
+	// Each call starts a new iteration.
+	got = nil
+	next(func(v interface{}) bool {
+		got = append(got, v)
+		return true
+	})
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, got)
+
+	// Empty slice.
+	SortedIter([]int{})(func(v interface{}) bool {
+		t.Errorf("unexpected value: %v", v)
+		return true
+	})
+}
+
+func TestSortedIter_stable(t *testing.T) {
+	t.Parallel()
+
+	type pair struct{ key, index int }
+	slice := make([]pair, 100)
+	for i := range slice {
+		slice[i] = pair{key: (i * 7) % 5, index: i}
+	}
+
+	var got []pair
+	By(func(a, b pair) int { return a.key - b.key }).SortedIter(slice)(func(v interface{}) bool {
+		got = append(got, v.(pair))
+		return true
+	})
+	want := append([]pair(nil), got...)
+	sort.Slice(want, func(i, j int) bool {
+		if want[i].key != want[j].key {
+			return want[i].key < want[j].key
+		}
+		return want[i].index < want[j].index
+	})
+	assert.Equal(t, want, got)
+	assert.Len(t, got, 100)
+}
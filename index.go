@@ -0,0 +1,82 @@
+package order
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// Index is a sorted view over a slice, which allows searching the slice without reordering it. It
+// stores the sorted order of the slice elements, such that the slice itself is not modified. The
+// slice should not be modified while the index is used.
+type Index struct {
+	fns Fns
+	s   reflectutil.Slice
+	// perm holds the indices of the slice elements in a stable sorted order.
+	perm []int
+}
+
+// Index returns an Index over the given slice, sorted according to the comparison functions. It
+// panics if the slice is not a slice of type T.
+func (fns Fns) Index(slice interface{}) *Index {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	return &Index{fns: fns, s: s, perm: fns.argsort(s)}
+}
+
+// Search returns the index in the original slice of an element that is equal to the given value.
+// Among equal elements, the first one in the original slice is returned. It returns -1 if no element
+// is equal to the given value. It panics if the value is not of type T.
+func (x *Index) Search(value interface{}) int {
+	v := x.fns.mustValue(reflect.ValueOf(value))
+	k := x.lowerBound(v)
+	if k == len(x.perm) || x.compare(k, v) != 0 {
+		return -1
+	}
+	return x.perm[k]
+}
+
+// At returns the k'th smallest element of the slice, and its index in the original slice.
+func (x *Index) At(k int) (i int, value interface{}) {
+	i = x.perm[k]
+	return i, x.s.Index(i).Interface()
+}
+
+// Len returns the number of elements in the index.
+func (x *Index) Len() int {
+	return len(x.perm)
+}
+
+// Range calls f sequentially for each element of the slice and its index in the original slice, in
+// sorted order. If f returns false, range stops the iteration.
+func (x *Index) Range(f func(i int, value interface{}) bool) {
+	x.rangeSorted(0, len(x.perm), f)
+}
+
+// RangeBetween calls f sequentially for each element of the slice in the range [from, to) and its
+// index in the original slice, in sorted order. If f returns false, range stops the iteration. It
+// panics if from or to are not of type T.
+func (x *Index) RangeBetween(from, to interface{}, f func(i int, value interface{}) bool) {
+	start := x.lowerBound(x.fns.mustValue(reflect.ValueOf(from)))
+	end := x.lowerBound(x.fns.mustValue(reflect.ValueOf(to)))
+	x.rangeSorted(start, end, f)
+}
+
+func (x *Index) rangeSorted(start, end int, f func(i int, value interface{}) bool) {
+	for k := start; k < end; k++ {
+		i := x.perm[k]
+		if !f(i, x.s.Index(i).Interface()) {
+			return
+		}
+	}
+}
+
+// compare compares the k'th smallest element with v.
+func (x *Index) compare(k int, v reflect.Value) int {
+	return x.fns.compare(x.s.Index(x.perm[k]), v)
+}
+
+// lowerBound returns the sorted position of the first element that is not less than v.
+func (x *Index) lowerBound(v reflect.Value) int {
+	return sort.Search(len(x.perm), func(k int) bool { return x.compare(k, v) >= 0 })
+}
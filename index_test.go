@@ -0,0 +1,52 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	log := []int{5, 1, 4, 1, 3}
+	x := intFn.Index(log)
+	assert.Equal(t, 5, x.Len())
+	assert.Equal(t, []int{5, 1, 4, 1, 3}, log, "slice should not be modified")
+
+	assert.Equal(t, 1, x.Search(1))
+	assert.Equal(t, 0, x.Search(5))
+	assert.Equal(t, 4, x.Search(3))
+	assert.Equal(t, -1, x.Search(2))
+	assert.Equal(t, -1, x.Search(6))
+
+	i, v := x.At(0)
+	assert.Equal(t, 1, i)
+	assert.Equal(t, 1, v)
+	i, v = x.At(4)
+	assert.Equal(t, 0, i)
+	assert.Equal(t, 5, v)
+
+	var got [][2]int
+	x.Range(func(i int, v interface{}) bool {
+		got = append(got, [2]int{i, v.(int)})
+		return true
+	})
+	assert.Equal(t, [][2]int{{1, 1}, {3, 1}, {4, 3}, {2, 4}, {0, 5}}, got)
+
+	got = nil
+	x.RangeBetween(1, 5, func(i int, v interface{}) bool {
+		got = append(got, [2]int{i, v.(int)})
+		return len(got) < 3
+	})
+	assert.Equal(t, [][2]int{{1, 1}, {3, 1}, {4, 3}}, got)
+}
+
+func TestIndex_invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { intFn.Index([]string{}) })
+	x := intFn.Index([]int{1})
+	assert.Panics(t, func() { x.Search("a") })
+	assert.Panics(t, func() { x.RangeBetween("a", 1, func(int, interface{}) bool { return true }) })
+}
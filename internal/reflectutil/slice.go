@@ -48,6 +48,30 @@ func (s Slice) Slice3(i, j, k int) Slice {
 	return s
 }
 
+// Copy returns a new slice, of the same type, that holds a copy of the elements of the slice. A copy
+// of a nil slice is a nil slice.
+func (s Slice) Copy() Slice {
+	if s.IsNil() {
+		return s
+	}
+	cp := reflect.MakeSlice(s.Type(), s.Len(), s.Len())
+	reflect.Copy(cp, s.Value)
+	return Slice{
+		Value: cp,
+		swap:  reflect.Swapper(cp.Interface()),
+	}
+}
+
+// Independent returns a slice over the same elements, with its own swap function. The swap function
+// that is created by `reflect.Swapper` may use a shared scratch space, such that slices that swap
+// elements concurrently, even different elements, should be independent of each other.
+func (s Slice) Independent() Slice {
+	return Slice{
+		Value: s.Value,
+		swap:  reflect.Swapper(s.Interface()),
+	}
+}
+
 // Swap swaps elements in position i and j.
 func (s Slice) Swap(i, j int) {
 	s.swap(i+s.swapOffset, j+s.swapOffset)
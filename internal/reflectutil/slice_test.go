@@ -103,4 +103,42 @@ func TestSlice_swap(t *testing.T) {
 		s.Slice3(1, 3, 3).Swap(0, 1)
 		assert.Equal(t, []int{1, 3, 2}, a)
 	})
+
+	t.Run("slice and independent swap", func(t *testing.T) {
+		a := []int{1, 2, 3, 4}
+		s, err := NewSlice(reflect.ValueOf(a))
+		require.NoError(t, err)
+		s.Slice(1, 4).Independent().Slice(1, 3).Swap(0, 1)
+		assert.Equal(t, []int{1, 2, 4, 3}, a)
+	})
+}
+
+func TestSlice_copy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("copy", func(t *testing.T) {
+		a := []int{1, 2, 3}
+		s, err := NewSlice(reflect.ValueOf(a))
+		require.NoError(t, err)
+		cp := s.Copy()
+		cp.Swap(0, 2)
+		assert.Equal(t, []int{1, 2, 3}, a)
+		assert.Equal(t, []int{3, 2, 1}, cp.Interface())
+	})
+
+	t.Run("slice and copy", func(t *testing.T) {
+		a := []int{1, 2, 3}
+		s, err := NewSlice(reflect.ValueOf(a))
+		require.NoError(t, err)
+		cp := s.Slice(1, 3).Copy()
+		cp.Swap(0, 1)
+		assert.Equal(t, []int{1, 2, 3}, a)
+		assert.Equal(t, []int{3, 2}, cp.Interface())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		s, err := NewSlice(reflect.ValueOf([]int(nil)))
+		require.NoError(t, err)
+		assert.Equal(t, []int(nil), s.Copy().Interface())
+	})
 }
@@ -2,6 +2,7 @@ package reflectutil
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 )
@@ -29,13 +30,10 @@ loop:
 			// If the type is a pointer, get the enderlying type and increment the pointer counter.
 			tp = tp.Elem()
 			t.ptrCount++
-		case reflect.Slice:
-			// Only allow slice of []byte.
-			if tp.Elem().Kind() == reflect.Uint8 {
-				break loop
-			}
-			return t, fmt.Errorf("slice (besides []byte) is not supported for T.")
-		case reflect.Array, reflect.Map, reflect.Func:
+		case reflect.Slice, reflect.Array, reflect.Map:
+			// Slices, arrays and maps are compared by their elements.
+			break loop
+		case reflect.Func:
 			return t, fmt.Errorf("%v is not supported for T.", tp.Kind())
 		default:
 			break loop
@@ -45,71 +43,194 @@ loop:
 	return t, nil
 }
 
-// Convert returns the given value as T. If the conversion is not possible, it returns false as the
-// second argument. It panics when the value can't be converted.
+// Convert returns the given value as T. It panics when the value can't be converted.
 func (t T) Convert(v reflect.Value) reflect.Value {
-	ok := t.convert(v.Type(), &v)
+	c, ok := t.conversion(v.Type())
 	if !ok {
 		panic(fmt.Sprintf("type %v can't be converted to: %v", v.Type(), t.Type))
 	}
-	return v
+	return c.apply(v)
+}
+
+// Converter returns a function that converts values of the given type to T, such that the
+// conversion is resolved once for multiple values of the same type. It returns false if the type
+// can't be converted to T.
+func (t T) Converter(src reflect.Type) (func(reflect.Value) reflect.Value, bool) {
+	c, ok := t.conversion(src)
+	if !ok {
+		return nil, false
+	}
+	if c == (conversion{}) {
+		// Exactly the same types.
+		return func(v reflect.Value) reflect.Value { return v }, true
+	}
+	return c.apply, true
 }
 
 // Check if another type is convertable to T.
 func (t T) Check(tp reflect.Type) bool {
-	return t.convert(tp, nil)
+	_, ok := t.conversion(tp)
+	return ok
 }
 
-// converts checks if src can be converted to T and applies the conversion on v if given.
-func (t T) convert(src reflect.Type, v *reflect.Value) (ok bool) {
-	dst := t.Type
-	// If the conversion was successful set v to be a pointer to T according to the T.ptrCount.
-	defer func() {
-		if !ok || v == nil {
-			return
-		}
-		for i := 0; i < t.ptrCount; i++ {
-			*v = ptrTo(*v)
+// CheckValue checks if a value of a type that is convertable to T can be converted to T. A floating
+// point value can be converted to an integer type only if it is exactly representable by it, see
+// kindConversionAllowed. Other values can always be converted.
+func (t T) CheckValue(v reflect.Value) bool {
+	c, ok := t.conversion(v.Type())
+	if !ok {
+		return false
+	}
+	if !c.exact {
+		return true
+	}
+	for i := 0; i < c.derefs; i++ {
+		if v.IsNil() {
+			// Not a floating point value.
+			return true
 		}
-	}()
+		v = v.Elem()
+	}
+	return exactInt(v.Float(), v.Convert(c.convert))
+}
+
+// Inexact checks if some values of a type that is convertable to T can't be converted to T, such
+// that values should be checked with CheckValue before they are converted.
+func (t T) Inexact(tp reflect.Type) bool {
+	c, ok := t.conversion(tp)
+	return ok && c.exact
+}
+
+// conversion describes the steps of converting values of a given type to T.
+type conversion struct {
+	// derefs is the number of pointers that should be dereferenced.
+	derefs int
+	// convert is the type that the dereferenced value should be converted to, or nil if it is
+	// already of the underlying type of T.
+	convert reflect.Type
+	// exact indicates that a floating point value is converted to an integer, which is allowed only
+	// for values that are exactly representable by the integer type.
+	exact bool
+	// ptrCount is the number of pointers that should be taken to the converted value, according to
+	// T.ptrCount.
+	ptrCount int
+}
+
+// conversion checks if src can be converted to T and returns the conversion steps.
+func (t T) conversion(src reflect.Type) (c conversion, ok bool) {
+	dst := t.Type
+	c.ptrCount = t.ptrCount
 	for {
 		switch {
 		case src == dst:
 			// Exactly the same types.
-			ok = true
-			return
+			return c, true
+		case dst.Kind() == reflect.Interface && src.Implements(dst):
+			// T is an interface which is implemented by src.
+			c.convert = dst
+			return c, true
 		case kindConversionAllowed(src, dst):
 			// The conversion between src to dst is allowed.
-			if v != nil {
-				*v = v.Convert(dst)
-			}
-			ok = true
-			return
+			c.convert = dst
+			c.exact = numKindOf(src.Kind()) == numFloat && numKindOf(dst.Kind()) != numFloat
+			return c, true
 		case src.Kind() == reflect.Ptr:
 			// src might be a pointer to dst, take the underlying object and look for dst.
-			if v != nil {
-				*v = v.Elem()
-			}
+			c.derefs++
 			src = src.Elem()
 		default:
-			return
+			return c, false
+		}
+	}
+}
+
+// apply applies the conversion on a value.
+func (c conversion) apply(v reflect.Value) reflect.Value {
+	for i := 0; i < c.derefs; i++ {
+		v = v.Elem()
+	}
+	if c.convert != nil {
+		f := v
+		v = v.Convert(c.convert)
+		if c.exact && !exactInt(f.Float(), v) {
+			panic(fmt.Sprintf("value %v can't be converted to %v without losing precision", f.Float(), c.convert))
 		}
 	}
+	// Set v to be a pointer to T according to the T.ptrCount.
+	for i := 0; i < c.ptrCount; i++ {
+		v = ptrTo(v)
+	}
+	return v
 }
 
 // kindConversionAllowed checks if the conversion from src to dst is allowed.
 func kindConversionAllowed(src reflect.Type, dst reflect.Type) bool {
-	// If the same kind return true, with an exception for struct in which src should be
-	// convertable to dst.
-	if src.Kind() == dst.Kind() && (dst.Kind() != reflect.Struct || src.ConvertibleTo(dst)) {
-		return true
+	// If the same kind return true, with an exception for struct, array, slice and map in which src
+	// should be convertable to dst.
+	if src.Kind() == dst.Kind() {
+		switch dst.Kind() {
+		case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map:
+			if src.ConvertibleTo(dst) {
+				return true
+			}
+		default:
+			return true
+		}
 	}
 
 	// For numerical kinds, allow converting the same numerical group where dst has number of bits
 	// greater or equal to src.
 	srcKindGroup := numKindOf(src.Kind())
 	dstKindGroup := numKindOf(dst.Kind())
-	return srcKindGroup != numNot && srcKindGroup == dstKindGroup && src.Bits() <= dst.Bits()
+	if srcKindGroup != numNot && srcKindGroup == dstKindGroup && src.Bits() <= dst.Bits() {
+		return true
+	}
+
+	// Strings and byte slices are interchangeable.
+	if isString(src) && isBytes(dst) || isBytes(src) && isString(dst) {
+		return true
+	}
+
+	// Allow promotions between the numerical groups that keep the sign and the magnitude of the
+	// value: unsigned integers to signed integers with more bits, and integers to floating point
+	// numbers. Integers of magnitude greater than 2^24 for float32 or 2^53 for float64 are rounded
+	// to the nearest representable value. Floating point numbers are converted to integers only if
+	// their value is exactly representable by the integer type, otherwise the conversion of the
+	// value panics, since their fraction or magnitude would be lost.
+	switch {
+	case srcKindGroup == numUint && dstKindGroup == numInt:
+		return src.Bits() < dst.Bits()
+	case (srcKindGroup == numInt || srcKindGroup == numUint) && dstKindGroup == numFloat:
+		return true
+	case srcKindGroup == numFloat && (dstKindGroup == numInt || dstKindGroup == numUint):
+		return true
+	default:
+		return false
+	}
+}
+
+// exactInt returns whether the integer value v, that was converted from f, is exactly equal to f.
+func exactInt(f float64, v reflect.Value) bool {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return false
+	}
+	switch numKindOf(v.Kind()) {
+	case numInt:
+		// float64(math.MaxInt64) is 2^63, which is out of range and converted to another value.
+		return f >= math.MinInt64 && f < math.MaxInt64 && float64(v.Int()) == f
+	default:
+		return f >= 0 && f < math.MaxUint64 && float64(v.Uint()) == f
+	}
+}
+
+// isString returns whether the type is of string kind.
+func isString(tp reflect.Type) bool {
+	return tp.Kind() == reflect.String
+}
+
+// isBytes returns whether the type is a slice of bytes.
+func isBytes(tp reflect.Type) bool {
+	return tp.Kind() == reflect.Slice && tp.Elem().Kind() == reflect.Uint8
 }
 
 // numKind represents a group of numerical kinds.
@@ -133,7 +254,7 @@ func numKindOf(k reflect.Kind) numKind {
 	case reflect.Float32, reflect.Float64:
 		return numFloat
 	case reflect.Complex64, reflect.Complex128:
-		return numFloat
+		return numComplex
 	default:
 		return numNot
 	}
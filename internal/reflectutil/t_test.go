@@ -2,6 +2,7 @@ package reflectutil
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
@@ -15,6 +16,9 @@ type (
 	u1 struct{ OtherField int }
 
 	myString string
+	myArray  [2]int
+	myInts   []int
+	myMap    map[string]int
 )
 
 var intT, _ = New(reflect.TypeOf(1))
@@ -23,14 +27,6 @@ func TestNew_failures(t *testing.T) {
 	t.Parallel()
 
 	var err error
-	_, err = New(reflect.TypeOf([8]byte{}))
-	assert.Error(t, err)
-	_, err = New(reflect.TypeOf([8]int{}))
-	assert.Error(t, err)
-	_, err = New(reflect.TypeOf([]int{}))
-	assert.Error(t, err)
-	_, err = New(reflect.TypeOf(map[int]int{}))
-	assert.Error(t, err)
 	_, err = New(reflect.TypeOf(func() {}))
 	assert.Error(t, err)
 }
@@ -48,6 +44,11 @@ func TestConvert_basicTypes(t *testing.T) {
 
 		assert.Equal(t, reflect.TypeOf(dst), got.Type())
 		assert.Equal(t, dst, got.Interface())
+
+		conv, ok := t1.Converter(reflect.TypeOf(src))
+		require.True(t, ok)
+		assert.Equal(t, dst, conv(reflect.ValueOf(src)).Interface())
+		assert.True(t, t1.CheckValue(reflect.ValueOf(src)))
 	}
 
 	checkFailure := func(t *testing.T, src, dst interface{}) {
@@ -57,6 +58,8 @@ func TestConvert_basicTypes(t *testing.T) {
 
 		assert.Panics(t, func() { t1.Convert(reflect.ValueOf(src)) })
 		assert.False(t, t1.Check(reflect.TypeOf(src)))
+		_, ok := t1.Converter(reflect.TypeOf(src))
+		assert.False(t, ok)
 
 	}
 
@@ -83,11 +86,51 @@ func TestConvert_basicTypes(t *testing.T) {
 		}
 	}
 
+	// Check promotions between numerical groups.
+	for _, values := range [][2]interface{}{
+		{uint8(1), int16(1)},
+		{uint32(1), int64(1)},
+		{int64(1), float32(1)},
+		{uint64(1), float64(1)},
+		{float64(3), int(3)},
+		{float32(-3), int8(-3)},
+		{float64(3), uint16(3)},
+	} {
+		src, dst := values[0], values[1]
+		t.Run(testName2(src, dst), func(t *testing.T) {
+			checkSuccess(t, src, dst)
+		})
+	}
+
+	// Floating point values are converted to integers only when they are exactly representable.
+	for _, values := range [][2]interface{}{
+		{1.5, int(1)},
+		{300.0, int8(1)},
+		{-1.0, uint(1)},
+		{math.NaN(), int(1)},
+		{math.Inf(1), int64(1)},
+		{float64(math.MaxInt64), int64(1)},
+	} {
+		src, dst := values[0], values[1]
+		t.Run(testName2(src, dst), func(t *testing.T) {
+			t1, err := New(reflect.TypeOf(dst))
+			require.NoError(t, err)
+			assert.True(t, t1.Check(reflect.TypeOf(src)))
+			assert.True(t, t1.Inexact(reflect.TypeOf(src)))
+			assert.False(t, t1.CheckValue(reflect.ValueOf(src)))
+			assert.Panics(t, func() { t1.Convert(reflect.ValueOf(src)) })
+		})
+	}
+
 	// Check both-way conversions between types.
 	for _, values := range [][]interface{}{
 		{int(1), intPtr(1)},
 		{"a", myString("a"), stringPtr("a"), myStringPtr("a")},
 		{t1{42}, t2{42}},
+		{[2]int{1, 2}, myArray{1, 2}},
+		{[]int{1, 2}, myInts{1, 2}},
+		{"abc", []byte("abc"), myString("abc")},
+		{map[string]int{"a": 1}, myMap{"a": 1}},
 	} {
 		for _, src := range values {
 			for _, dst := range values {
@@ -99,6 +142,23 @@ func TestConvert_basicTypes(t *testing.T) {
 	}
 }
 
+func TestConvert_interface(t *testing.T) {
+	t.Parallel()
+
+	stringer, err := New(reflect.TypeOf((*fmt.Stringer)(nil)).Elem())
+	require.NoError(t, err)
+
+	// Both the value and a pointer to it implement the interface.
+	for _, src := range []interface{}{myStringer("a"), myStringerPtr("a")} {
+		got := stringer.Convert(reflect.ValueOf(src))
+		assert.Equal(t, reflect.Interface, got.Kind())
+		assert.Equal(t, "a", got.Interface().(fmt.Stringer).String())
+	}
+
+	assert.False(t, stringer.Check(reflect.TypeOf("a")))
+	assert.Panics(t, func() { stringer.Convert(reflect.ValueOf("a")) })
+}
+
 func TestConvert_failures(t *testing.T) {
 	t.Parallel()
 
@@ -107,9 +167,13 @@ func TestConvert_failures(t *testing.T) {
 		dst, src interface{}
 	}{
 		{dst: "", src: 1},
+		{dst: float64(0), src: complex64(0)},
+		{dst: complex128(0), src: float32(0)},
+		{dst: int64(0), src: uint64(0)},
+		{dst: uint(0), src: 1},
 		{dst: 1, src: "1"},
-		{dst: "", src: []byte("")},
-		{dst: []byte(""), src: ""},
+		{dst: "", src: []int32{}},
+		{dst: []int32{}, src: ""},
 		{dst: stringPtr(""), src: 1},
 		{dst: 1, src: stringPtr("")},
 		{dst: stringPtr(""), src: intPtr(1)},
@@ -118,6 +182,12 @@ func TestConvert_failures(t *testing.T) {
 		{dst: u1{}, src: t1{}},
 		{dst: "", src: []string{""}},
 		{dst: "", src: [1]string{""}},
+		{dst: [2]int{}, src: [3]int{}},
+		{dst: [2]int{}, src: [2]string{}},
+		{dst: []int{}, src: []string{}},
+		{dst: []int{}, src: [2]int{}},
+		{dst: map[string]int{}, src: map[string]string{}},
+		{dst: map[string]int{}, src: map[int]int{}},
 		{dst: "", src: map[string]string{"": ""}},
 		{dst: "", src: func() {}},
 	}
@@ -129,6 +199,8 @@ func TestConvert_failures(t *testing.T) {
 
 			assert.Panics(t, func() { t1.Convert(reflect.ValueOf(tt.src)) })
 			assert.False(t, t1.Check(reflect.TypeOf(tt.src)))
+			_, ok := t1.Converter(reflect.TypeOf(tt.src))
+			assert.False(t, ok)
 		})
 	}
 }
@@ -148,3 +220,12 @@ func myStringPtr(s string) *myString {
 
 func testName(v interface{}) string         { return fmt.Sprintf("%T(%v)", v, v) }
 func testName2(src, dst interface{}) string { return fmt.Sprintf("%T(%v)/%T(%v)", src, src, dst, dst) }
+
+type myStringer string
+
+func (s myStringer) String() string { return string(s) }
+
+func myStringerPtr(s string) *myStringer {
+	m := myStringer(s)
+	return &m
+}
@@ -0,0 +1,127 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// SymmetricDifference returns the elements that are present in exactly one of the given slices. Both
+// slices should be sorted relative to the comparison function. Equal elements are matched one to
+// one, such that if a value appears n times in `a` and m times in `b`, it will appear |n-m| times in
+// the result. The result is sorted, and is of the type of slice `a`, elements of `b` are converted to
+// the element type of `a`.
+func (fns Fns) SymmetricDifference(a, b interface{}) interface{} {
+	sa := fns.mustSlice(reflect.ValueOf(a))
+	sb := fns.mustSlice(reflect.ValueOf(b))
+	convert := mustConverter(sa.T(), sb.T())
+
+	result := reflect.MakeSlice(sa.Type(), 0, 0)
+	fns.merge(sa, sb,
+		func(i int) { result = reflect.Append(result, sa.Index(i)) },
+		func(j int) { result = reflect.Append(result, convert(sb.Index(j))) },
+		func(i, j int) {},
+	)
+	return result.Interface()
+}
+
+// Diff returns the elements that were added and removed between two sorted slices. The `added`
+// slice holds the elements of `new` that are not in `old`, and is of the type of `new`. The
+// `removed` slice holds the elements of `old` that are not in `new`, and is of the type of `old`.
+// Both slices should be sorted relative to the comparison function. Equal elements are matched one
+// to one, such that if a value appears more times in `new` than in `old`, the extra appearances are
+// reported as added, and vice versa.
+func (fns Fns) Diff(old, new interface{}) (added, removed interface{}) {
+	sOld := fns.mustSlice(reflect.ValueOf(old))
+	sNew := fns.mustSlice(reflect.ValueOf(new))
+
+	a := reflect.MakeSlice(sNew.Type(), 0, 0)
+	r := reflect.MakeSlice(sOld.Type(), 0, 0)
+	fns.merge(sOld, sNew,
+		func(i int) { r = reflect.Append(r, sOld.Index(i)) },
+		func(j int) { a = reflect.Append(a, sNew.Index(j)) },
+		func(i, j int) {},
+	)
+	return a.Interface(), r.Interface()
+}
+
+// Join performs a sort-merge join of two sorted slices. It calls emit with the indices of every pair
+// of equal elements of `a` and `b`. If a value appears n times in `a` and m times in `b`, emit is
+// called n*m times for this value. Pairs are emitted in order of `a` and then of `b`. Both slices
+// should be sorted relative to the comparison function.
+func (fns Fns) Join(a, b interface{}, emit func(i, j int)) {
+	sa := fns.mustSlice(reflect.ValueOf(a))
+	sb := fns.mustSlice(reflect.ValueOf(b))
+
+	i, j := 0, 0
+	for i < sa.Len() && j < sb.Len() {
+		cmp := fns.compare(sa.Index(i), sb.Index(j))
+		switch {
+		case cmp < 0: // a[i] < b[j]
+			i++
+		case cmp > 0: // a[i] > b[j]
+			j++
+		default: // a[i] == b[j]
+			// Find the end of the runs of equal elements in both slices.
+			iEnd, jEnd := fns.runEnd(sa, i), fns.runEnd(sb, j)
+			for ii := i; ii < iEnd; ii++ {
+				for jj := j; jj < jEnd; jj++ {
+					emit(ii, jj)
+				}
+			}
+			i, j = iEnd, jEnd
+		}
+	}
+}
+
+// runEnd returns the index following the run of elements that are equal to s[i].
+func (fns Fns) runEnd(s reflectutil.Slice, i int) int {
+	end := i + 1
+	for end < s.Len() && fns.compare(s.Index(i), s.Index(end)) == 0 {
+		end++
+	}
+	return end
+}
+
+// merge walks over two sorted slices in order. For each element that exists only in `a`, the
+// `onlyA` function is called with its index, for each element that exists only in `b`, the `onlyB`
+// function is called with its index. For each pair of equal elements the `both` function is called
+// with the indices of the elements in `a` and `b`.
+func (fns Fns) merge(a, b reflectutil.Slice, onlyA, onlyB func(i int), both func(i, j int)) {
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		cmp := fns.compare(a.Index(i), b.Index(j))
+		switch {
+		case cmp < 0: // a[i] < b[j]
+			onlyA(i)
+			i++
+		case cmp > 0: // a[i] > b[j]
+			onlyB(j)
+			j++
+		default: // a[i] == b[j]
+			both(i, j)
+			i++
+			j++
+		}
+	}
+	for ; i < a.Len(); i++ {
+		onlyA(i)
+	}
+	for ; j < b.Len(); j++ {
+		onlyB(j)
+	}
+}
+
+// mustConverter returns a function that converts values of type src to type dst. It panics if the
+// conversion is not possible.
+func mustConverter(dst, src reflect.Type) func(reflect.Value) reflect.Value {
+	if dst == src {
+		return func(v reflect.Value) reflect.Value { return v }
+	}
+	t, err := reflectutil.New(dst)
+	if err != nil || !t.Check(src) {
+		panic(fmt.Sprintf("slice elements of type %v can't be converted to %v", src, dst))
+	}
+	return t.Convert
+}
@@ -0,0 +1,99 @@
+package order
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymmetricDifference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b []int
+		want []int
+	}{
+		{a: []int{}, b: []int{}, want: []int{}},
+		{a: []int{1, 2}, b: []int{}, want: []int{1, 2}},
+		{a: []int{}, b: []int{1, 2}, want: []int{1, 2}},
+		{a: []int{1, 2, 3}, b: []int{1, 2, 3}, want: []int{}},
+		{a: []int{1, 3, 5}, b: []int{2, 3, 4}, want: []int{1, 2, 4, 5}},
+		{a: []int{1, 1, 1, 2}, b: []int{1, 2, 2}, want: []int{1, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.a, tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.want, SymmetricDifference(tt.a, tt.b))
+		})
+	}
+}
+
+func TestSymmetricDifference_convert(t *testing.T) {
+	t.Parallel()
+
+	got := intFn.SymmetricDifference([]int{1, 3}, []int8{2, 3})
+	assert.Equal(t, []int{1, 2}, got)
+
+	// Elements of b can't be converted to the elements of a.
+	assert.Panics(t, func() { intFn.SymmetricDifference([]int8{1, 3}, []int{2, 3}) })
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		old, new    []int
+		wantAdded   []int
+		wantRemoved []int
+	}{
+		{old: []int{}, new: []int{}, wantAdded: []int{}, wantRemoved: []int{}},
+		{old: []int{}, new: []int{1, 2}, wantAdded: []int{1, 2}, wantRemoved: []int{}},
+		{old: []int{1, 2}, new: []int{}, wantAdded: []int{}, wantRemoved: []int{1, 2}},
+		{old: []int{1, 2, 3}, new: []int{1, 2, 3}, wantAdded: []int{}, wantRemoved: []int{}},
+		{old: []int{1, 3, 5}, new: []int{2, 3, 4}, wantAdded: []int{2, 4}, wantRemoved: []int{1, 5}},
+		{old: []int{1, 2, 2}, new: []int{1, 1, 2}, wantAdded: []int{1}, wantRemoved: []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.old, tt.new), func(t *testing.T) {
+			gotAdded, gotRemoved := Diff(tt.old, tt.new)
+			assert.Equal(t, tt.wantAdded, gotAdded)
+			assert.Equal(t, tt.wantRemoved, gotRemoved)
+		})
+	}
+}
+
+func TestDiff_types(t *testing.T) {
+	t.Parallel()
+
+	added, removed := intFn.Diff([]int8{1, 2}, []int16{2, 3})
+	assert.Equal(t, []int16{3}, added)
+	assert.Equal(t, []int8{1}, removed)
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b []int
+		want [][2]int
+	}{
+		{a: []int{}, b: []int{1}, want: nil},
+		{a: []int{1, 2}, b: []int{3, 4}, want: nil},
+		{a: []int{1, 2, 3}, b: []int{2, 3, 4}, want: [][2]int{{1, 0}, {2, 1}}},
+		{
+			a:    []int{1, 2, 2, 3},
+			b:    []int{0, 2, 2, 2, 3},
+			want: [][2]int{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {2, 2}, {2, 3}, {3, 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.a, tt.b), func(t *testing.T) {
+			var got [][2]int
+			Join(tt.a, tt.b, func(i, j int) { got = append(got, [2]int{i, j}) })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Migration is a step of a migration, identified by its version.
+type Migration struct {
+	// Version of the migration step, of type T.
+	Version interface{}
+	// Apply performs the migration step.
+	Apply func() error
+}
+
+// Migrations holds migration steps, and runs them in the order of their versions, according to
+// comparison functions. For example, with a comparison function of integers:
+//
+//	m := order.NewMigrations(order.By(func(a, b int) int { return a - b }))
+//	m.Register(2, addIndex)
+//	m.Register(1, createTable)
+//	// Run the steps that were not applied yet, and store the cursor to resume from it later.
+//	cursor, err := m.Run(loadCursor())
+//
+// Migrations is not safe for concurrent use.
+type Migrations struct {
+	fns   Fns
+	steps []Migration
+}
+
+// NewMigrations returns an empty Migrations, with versions that are ordered by the given comparison
+// functions.
+func NewMigrations(fns Fns) *Migrations {
+	return &Migrations{fns: fns}
+}
+
+// Register adds a migration step with the given version. Steps may be registered in any order. It
+// panics if the version is not of type T.
+func (m *Migrations) Register(version interface{}, apply func() error) {
+	m.fns.mustValue(reflect.ValueOf(version))
+	m.steps = append(m.steps, Migration{Version: version, Apply: apply})
+}
+
+// Pending returns the migration steps with a version that is greater than the `after` cursor, in
+// increasing order of versions. A nil cursor returns all the steps. It returns an error if two steps
+// were registered with equal versions. It panics if the cursor is not of type T.
+func (m *Migrations) Pending(after interface{}) ([]Migration, error) {
+	steps := make([]Migration, len(m.steps))
+	copy(steps, m.steps)
+	sort.SliceStable(steps, func(i, j int) bool { return m.compare(steps[i], steps[j].Version) < 0 })
+	for i := 1; i < len(steps); i++ {
+		if m.compare(steps[i-1], steps[i].Version) == 0 {
+			return nil, fmt.Errorf("duplicate migration version: %v and %v", steps[i-1].Version, steps[i].Version)
+		}
+	}
+
+	if after == nil {
+		return steps, nil
+	}
+	a := m.fns.mustValue(reflect.ValueOf(after))
+	i := sort.Search(len(steps), func(i int) bool { return m.fns.compare(reflect.ValueOf(steps[i].Version), a) > 0 })
+	return steps[i:], nil
+}
+
+// Run applies the pending migration steps after the given cursor, as returned by Pending, in order.
+// It returns the version of the last step that was applied, or the given cursor if no step was
+// applied, which should be used as the cursor to resume from in the following runs. If a step fails,
+// Run stops and returns its error, and the cursor of the last successful step.
+func (m *Migrations) Run(after interface{}) (cursor interface{}, err error) {
+	steps, err := m.Pending(after)
+	if err != nil {
+		return after, err
+	}
+	cursor = after
+	for _, step := range steps {
+		if err := step.Apply(); err != nil {
+			return cursor, fmt.Errorf("migration %v: %w", step.Version, err)
+		}
+		cursor = step.Version
+	}
+	return cursor, nil
+}
+
+// compare compares the version of a step with a version.
+func (m *Migrations) compare(step Migration, version interface{}) int {
+	return m.fns.compare(reflect.ValueOf(step.Version), reflect.ValueOf(version))
+}
@@ -0,0 +1,86 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+
+	var applied []int
+	step := func(v int) func() error {
+		return func() error {
+			applied = append(applied, v)
+			return nil
+		}
+	}
+
+	m := NewMigrations(intFn)
+	m.Register(3, step(3))
+	m.Register(1, step(1))
+	m.Register(2, step(2))
+
+	cursor, err := m.Run(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, cursor)
+	assert.Equal(t, []int{1, 2, 3}, applied)
+
+	// Resume from the cursor after registering more steps.
+	m.Register(5, step(5))
+	m.Register(4, step(4))
+	cursor, err = m.Run(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, 5, cursor)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, applied)
+
+	// Nothing to apply.
+	cursor, err = m.Run(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, 5, cursor)
+
+	pending, err := m.Pending(2)
+	require.NoError(t, err)
+	var versions []interface{}
+	for _, p := range pending {
+		versions = append(versions, p.Version)
+	}
+	assert.Equal(t, []interface{}{3, 4, 5}, versions)
+}
+
+func TestMigrations_failures(t *testing.T) {
+	t.Parallel()
+
+	errFail := errors.New("fail")
+	ok := func() error { return nil }
+
+	t.Run("step error", func(t *testing.T) {
+		m := NewMigrations(intFn)
+		m.Register(1, ok)
+		m.Register(2, func() error { return errFail })
+		m.Register(3, ok)
+		cursor, err := m.Run(nil)
+		assert.True(t, errors.Is(err, errFail))
+		assert.EqualError(t, err, "migration 2: fail")
+		assert.Equal(t, 1, cursor)
+	})
+
+	t.Run("duplicate version", func(t *testing.T) {
+		m := NewMigrations(intFn)
+		m.Register(1, ok)
+		m.Register(2, ok)
+		m.Register(1, ok)
+		cursor, err := m.Run(nil)
+		assert.EqualError(t, err, "duplicate migration version: 1 and 1")
+		assert.Nil(t, cursor)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		m := NewMigrations(intFn)
+		assert.Panics(t, func() { m.Register("1", ok) })
+		assert.Panics(t, func() { m.Run("1") })
+	})
+}
@@ -0,0 +1,171 @@
+package order
+
+import (
+	"math/bits"
+	"reflect"
+)
+
+// MinMaxHeap is a double ended priority queue of values, that allows popping both the smallest and
+// the greatest values according to comparison functions in O(log n) time. Equal values are popped in
+// an unspecified order. A MinMaxHeap is not safe for concurrent use.
+type MinMaxHeap struct {
+	fns Fns
+	// values are ordered as a min-max heap: values in even levels of the tree are smaller than
+	// their descendants, and values in odd levels are greater than their descendants.
+	values []interface{}
+}
+
+// NewMinMaxHeap returns an empty MinMaxHeap of values of type T, ordered by the given comparison
+// functions.
+func NewMinMaxHeap(fns Fns) *MinMaxHeap {
+	return &MinMaxHeap{fns: fns}
+}
+
+// Push pushes a value to the heap. It panics if the value is not of type T.
+func (h *MinMaxHeap) Push(value interface{}) {
+	h.fns.mustValue(reflect.ValueOf(value))
+	h.values = append(h.values, value)
+	h.bubbleUp(len(h.values) - 1)
+}
+
+// Min returns the smallest value in the heap. It panics if the heap is empty.
+func (h *MinMaxHeap) Min() interface{} {
+	return h.values[0]
+}
+
+// Max returns the greatest value in the heap. It panics if the heap is empty.
+func (h *MinMaxHeap) Max() interface{} {
+	return h.values[h.maxIndex()]
+}
+
+// PopMin removes and returns the smallest value in the heap. It panics if the heap is empty.
+func (h *MinMaxHeap) PopMin() interface{} {
+	return h.removeAt(0)
+}
+
+// PopMax removes and returns the greatest value in the heap. It panics if the heap is empty.
+func (h *MinMaxHeap) PopMax() interface{} {
+	return h.removeAt(h.maxIndex())
+}
+
+// Len returns the number of values in the heap.
+func (h *MinMaxHeap) Len() int {
+	return len(h.values)
+}
+
+// maxIndex returns the index of the greatest value, which is one of the root children, or the root
+// itself if it has no children.
+func (h *MinMaxHeap) maxIndex() int {
+	switch n := len(h.values); {
+	case n == 0:
+		panic("empty heap")
+	case n == 1:
+		return 0
+	case n == 2 || h.less(2, 1):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt removes the value at index i, by replacing it with the last value and fixing the heap.
+func (h *MinMaxHeap) removeAt(i int) interface{} {
+	value := h.values[i]
+	n := len(h.values) - 1
+	h.values[i] = h.values[n]
+	// Allow garbage collection of the removed value.
+	h.values[n] = nil
+	h.values = h.values[:n]
+	if i < n {
+		h.trickleDown(i)
+	}
+	return value
+}
+
+// bubbleUp moves the value at index i up the tree until the heap order is restored.
+func (h *MinMaxHeap) bubbleUp(i int) {
+	if i == 0 {
+		return
+	}
+	p := parent(i)
+	min := isMinLevel(i)
+	if h.before(p, i, min) {
+		// The value belongs to the levels of its parent.
+		h.swap(i, p)
+		h.bubbleUpLevels(p, !min)
+	} else {
+		h.bubbleUpLevels(i, min)
+	}
+}
+
+// bubbleUpLevels moves the value at index i up the tree along the grandparents, which are in the
+// same level kind (min or max) of the value.
+func (h *MinMaxHeap) bubbleUpLevels(i int, min bool) {
+	for i > 2 {
+		g := parent(parent(i))
+		if !h.before(i, g, min) {
+			return
+		}
+		h.swap(i, g)
+		i = g
+	}
+}
+
+// trickleDown moves the value at index i down the tree until the heap order is restored.
+func (h *MinMaxHeap) trickleDown(i int) {
+	min := isMinLevel(i)
+	for {
+		// Find the first in order among the children and grandchildren of i.
+		m, grandchild := -1, false
+		for c := 2*i + 1; c <= 2*i+2 && c < len(h.values); c++ {
+			if m < 0 || h.before(c, m, min) {
+				m, grandchild = c, false
+			}
+			for g := 2*c + 1; g <= 2*c+2 && g < len(h.values); g++ {
+				if h.before(g, m, min) {
+					m, grandchild = g, true
+				}
+			}
+		}
+		if m < 0 || !h.before(m, i, min) {
+			return
+		}
+		h.swap(m, i)
+		if !grandchild {
+			return
+		}
+		// The value that was moved to the grandchild position might be out of order with the
+		// parent of that position, which is in a level of the other kind.
+		if p := parent(m); h.before(p, m, min) {
+			h.swap(m, p)
+		}
+		i = m
+	}
+}
+
+// before returns whether the value at index i should be before the value at index j, in a min level
+// if min is true, or in a max level otherwise.
+func (h *MinMaxHeap) before(i, j int, min bool) bool {
+	if min {
+		return h.less(i, j)
+	}
+	return h.less(j, i)
+}
+
+func (h *MinMaxHeap) less(i, j int) bool {
+	return h.fns.compare(reflect.ValueOf(h.values[i]), reflect.ValueOf(h.values[j])) < 0
+}
+
+func (h *MinMaxHeap) swap(i, j int) {
+	h.values[i], h.values[j] = h.values[j], h.values[i]
+}
+
+// parent returns the index of the parent of index i in a binary heap.
+func parent(i int) int {
+	return (i - 1) / 2
+}
+
+// isMinLevel returns whether index i is in a min level of a min-max heap.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
@@ -0,0 +1,64 @@
+package order
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	t.Parallel()
+
+	h := NewMinMaxHeap(intFn)
+	assert.Equal(t, 0, h.Len())
+	assert.Panics(t, func() { h.Min() })
+	assert.Panics(t, func() { h.Max() })
+	assert.Panics(t, func() { h.PopMin() })
+	assert.Panics(t, func() { h.PopMax() })
+
+	for _, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		h.Push(v)
+	}
+	assert.Equal(t, 8, h.Len())
+	assert.Equal(t, 1, h.Min())
+	assert.Equal(t, 9, h.Max())
+
+	assert.Equal(t, 9, h.PopMax())
+	assert.Equal(t, 1, h.PopMin())
+	assert.Equal(t, 1, h.PopMin())
+	assert.Equal(t, 6, h.PopMax())
+	assert.Equal(t, 4, h.Len())
+	assert.Equal(t, 2, h.Min())
+	assert.Equal(t, 5, h.Max())
+
+	assert.Panics(t, func() { h.Push("1") })
+}
+
+func TestMinMaxHeap_random(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(0))
+	h := NewMinMaxHeap(intFn)
+	var want []int
+	for i := 0; i < 2000; i++ {
+		switch op := rnd.Intn(4); {
+		case op < 2 || len(want) == 0:
+			v := rnd.Intn(100)
+			h.Push(v)
+			want = append(want, v)
+			sort.Ints(want)
+		case op == 2:
+			assert.Equal(t, want[0], h.PopMin())
+			want = want[1:]
+		default:
+			assert.Equal(t, want[len(want)-1], h.PopMax())
+			want = want[:len(want)-1]
+		}
+		if assert.Equal(t, len(want), h.Len()) && len(want) > 0 {
+			assert.Equal(t, want[0], h.Min())
+			assert.Equal(t, want[len(want)-1], h.Max())
+		}
+	}
+}
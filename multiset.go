@@ -0,0 +1,86 @@
+package order
+
+import (
+	"reflect"
+)
+
+// Multiset is a collection of values that may contain equal values, where values are equal if the
+// comparison functions return zero for them. The values are kept sorted. A Multiset is not safe for
+// concurrent use.
+type Multiset struct {
+	list *SortedList
+}
+
+// NewMultiset returns an empty Multiset of values of type T, ordered by the given comparison
+// functions.
+func NewMultiset(fns Fns) *Multiset {
+	return &Multiset{list: NewSortedList(fns)}
+}
+
+// Add adds a value to the multiset. It panics if the value is not of type T.
+func (m *Multiset) Add(value interface{}) {
+	m.list.Insert(value)
+}
+
+// Count returns the number of values in the multiset that are equal to the given value.
+func (m *Multiset) Count(value interface{}) int {
+	start, end := m.bounds(value)
+	return end - start
+}
+
+// RemoveOne removes one value that is equal to the given value from the multiset. It returns
+// whether a value was removed.
+func (m *Multiset) RemoveOne(value interface{}) bool {
+	return m.list.Delete(value)
+}
+
+// RemoveAll removes all the values that are equal to the given value from the multiset. It returns
+// the number of removed values.
+func (m *Multiset) RemoveAll(value interface{}) int {
+	start, end := m.bounds(value)
+	m.list.deleteRange(start, end)
+	return end - start
+}
+
+// At returns the k'th smallest value of the multiset, counting equal values separately. It panics if
+// k is out of range.
+func (m *Multiset) At(k int) interface{} {
+	return m.list.At(k)
+}
+
+// Len returns the number of values in the multiset, counting equal values separately.
+func (m *Multiset) Len() int {
+	return m.list.Len()
+}
+
+// Range calls f sequentially for each value in the multiset, in order, with the number of values
+// that are equal to it. If f returns false, range stops the iteration. The multiset should not be
+// modified during the iteration.
+func (m *Multiset) Range(f func(value interface{}, count int) bool) {
+	values := m.list.values
+	for i := 0; i < len(values); {
+		end := m.list.upperBound(reflect.ValueOf(values[i]))
+		if !f(values[i], end-i) {
+			return
+		}
+		i = end
+	}
+}
+
+// AppendTo appends the values of the multiset, in order, to the slice that dstPtr points to. See
+// SortedList.AppendTo. Values that appear more than once are appended once for each appearance.
+func (m *Multiset) AppendTo(dstPtr interface{}) {
+	m.list.AppendTo(dstPtr)
+}
+
+// CopyRange sets the slice that dstPtr points to, to the values of the multiset that are greater or
+// equal to lo, and less than hi, in order once for each appearance. See SortedList.CopyRange.
+func (m *Multiset) CopyRange(dstPtr interface{}, lo, hi interface{}) {
+	m.list.CopyRange(dstPtr, lo, hi)
+}
+
+// bounds returns the index range of the values that are equal to the given value.
+func (m *Multiset) bounds(value interface{}) (start, end int) {
+	v := m.list.fns.mustValue(reflect.ValueOf(value))
+	return m.list.lowerBound(v), m.list.upperBound(v)
+}
@@ -0,0 +1,81 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiset(t *testing.T) {
+	t.Parallel()
+
+	m := NewMultiset(intFn)
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Count(1))
+
+	for _, v := range []int{3, 1, 2, 3, 1, 3} {
+		m.Add(v)
+	}
+	assert.Equal(t, 6, m.Len())
+	assert.Equal(t, 2, m.Count(1))
+	assert.Equal(t, 1, m.Count(2))
+	assert.Equal(t, 3, m.Count(3))
+	assert.Equal(t, 0, m.Count(4))
+
+	// K'th element.
+	assert.Equal(t, 1, m.At(0))
+	assert.Equal(t, 1, m.At(1))
+	assert.Equal(t, 2, m.At(2))
+	assert.Equal(t, 3, m.At(5))
+	assert.Panics(t, func() { m.At(6) })
+
+	assert.Equal(t, [][2]int{{1, 2}, {2, 1}, {3, 3}}, multisetCounts(m))
+
+	assert.True(t, m.RemoveOne(3))
+	assert.Equal(t, 2, m.Count(3))
+	assert.False(t, m.RemoveOne(4))
+
+	assert.Equal(t, 2, m.RemoveAll(1))
+	assert.Equal(t, 0, m.RemoveAll(1))
+	assert.Equal(t, [][2]int{{2, 1}, {3, 2}}, multisetCounts(m))
+	assert.Equal(t, 3, m.Len())
+}
+
+func TestMultiset_rangeStop(t *testing.T) {
+	t.Parallel()
+
+	m := NewMultiset(intFn)
+	m.Add(1)
+	m.Add(2)
+
+	n := 0
+	m.Range(func(interface{}, int) bool {
+		n++
+		return false
+	})
+	assert.Equal(t, 1, n)
+}
+
+func multisetCounts(m *Multiset) [][2]int {
+	var counts [][2]int
+	m.Range(func(v interface{}, count int) bool {
+		counts = append(counts, [2]int{v.(int), count})
+		return true
+	})
+	return counts
+}
+
+func TestMultiset_AppendTo(t *testing.T) {
+	t.Parallel()
+
+	m := NewMultiset(intFn)
+	for _, v := range []int{2, 1, 2} {
+		m.Add(v)
+	}
+
+	var dst []int
+	m.AppendTo(&dst)
+	assert.Equal(t, []int{1, 2, 2}, dst)
+	m.CopyRange(&dst, 2, 3)
+	assert.Equal(t, []int{2, 2}, dst)
+}
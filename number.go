@@ -0,0 +1,135 @@
+package order
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// numberFns returns comparison functions of a numeric type T, for the package level functions.
+// The values are compared by their numeric value, also with values of other numeric types, such
+// that `Is(3).Less(3.5)` is true. Integers are compared exactly with floating point numbers, and
+// NaN values are greater than all other values, as in NaNLast.
+func numberFns(tp reflect.Type) (Fns, error) {
+	t, err := reflectutil.New(tp)
+	if err != nil {
+		return nil, err
+	}
+	return natural(Fns{{fn: compareNumbers, t: t, calibration: new(calibration)}}), nil
+}
+
+// numberKind is the group of numeric kinds of a value.
+type numberKind int
+
+const (
+	notNumber numberKind = iota
+	intNumber
+	uintNumber
+	floatNumber
+)
+
+// numberKindOf returns the group of numeric kinds of a kind.
+func numberKindOf(k reflect.Kind) numberKind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intNumber
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintNumber
+	case reflect.Float32, reflect.Float64:
+		return floatNumber
+	default:
+		return notNumber
+	}
+}
+
+// compareNumbers is a three-way comparison of two numeric values, or pointers to numeric values,
+// that might be of different numeric kinds. It panics if a value is not numeric.
+func compareNumbers(lhs, rhs reflect.Value) int {
+	a, b := indirect(lhs), indirect(rhs)
+	ka, kb := numberKindOf(a.Kind()), numberKindOf(b.Kind())
+	switch {
+	case ka == notNumber || kb == notNumber:
+		panic(fmt.Sprintf("can't compare %v with %v", lhs.Type(), rhs.Type()))
+	case ka == intNumber && kb == intNumber:
+		return compareInt64(a.Int(), b.Int())
+	case ka == uintNumber && kb == uintNumber:
+		return compareUint64(a.Uint(), b.Uint())
+	case ka == floatNumber && kb == floatNumber:
+		return compareFloat(a.Float(), b.Float(), 1)
+	case ka == intNumber && kb == uintNumber:
+		if a.Int() < 0 {
+			return -1
+		}
+		return compareUint64(uint64(a.Int()), b.Uint())
+	case ka == intNumber && kb == floatNumber:
+		return compareIntFloat(a.Int(), b.Float())
+	case ka == uintNumber && kb == floatNumber:
+		return compareUintFloat(a.Uint(), b.Float())
+	default:
+		// A float with an integer, or an unsigned integer with a signed integer.
+		return -compareNumbers(b, a)
+	}
+}
+
+// compareIntFloat is an exact three-way comparison of an integer and a floating point number.
+func compareIntFloat(i int64, f float64) int {
+	switch {
+	case math.IsNaN(f), f >= math.MaxInt64:
+		// float64(math.MaxInt64) is 2^63, which is greater than all int64 values.
+		return -1
+	case f < math.MinInt64:
+		return 1
+	}
+	// The truncated value of f is in the int64 range, and it is exactly representable as float.
+	trunc := int64(f)
+	if i != trunc {
+		return compareInt64(i, trunc)
+	}
+	return compareFloat(0, f-float64(trunc), 1)
+}
+
+// compareUintFloat is an exact three-way comparison of an unsigned integer and a floating point
+// number.
+func compareUintFloat(u uint64, f float64) int {
+	switch {
+	case math.IsNaN(f), f >= math.MaxUint64:
+		// float64(math.MaxUint64) is 2^64, which is greater than all uint64 values.
+		return -1
+	case f < 0:
+		return 1
+	}
+	trunc := uint64(f)
+	if u != trunc {
+		return compareUint64(u, trunc)
+	}
+	return compareFloat(0, f-float64(trunc), 1)
+}
+
+// areNumbers returns whether the given types are of numeric kinds, that can be compared with
+// compareNumbers.
+func areNumbers(types ...reflect.Type) bool {
+	for _, tp := range types {
+		if numberKindOf(tp.Kind()) == notNumber {
+			return false
+		}
+	}
+	return true
+}
+
+// indirectType returns the type that a pointer type points to, through any number of pointers.
+func indirectType(tp reflect.Type) reflect.Type {
+	for tp.Kind() == reflect.Ptr {
+		tp = tp.Elem()
+	}
+	return tp
+}
+
+// indirect returns the value that a pointer value points to, through any number of pointers.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,92 @@
+package order
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	tests := []struct {
+		a, b interface{}
+		want int
+	}{
+		{a: 1, b: 2, want: -1},
+		{a: int8(-1), b: int64(-1), want: 0},
+		{a: uint8(2), b: uint64(1), want: 1},
+		{a: 1, b: uint(1), want: 0},
+		{a: -1, b: uint64(math.MaxUint64), want: -1},
+		{a: int64(math.MaxInt64), b: uint64(math.MaxInt64 + 1), want: -1},
+		{a: 3, b: 3.5, want: -1},
+		{a: 4, b: 3.5, want: 1},
+		{a: -3, b: -3.5, want: 1},
+		{a: 3, b: float32(3), want: 0},
+		{a: 0, b: math.Copysign(0, -1), want: 0},
+		{a: int64(math.MaxInt64), b: float64(math.MaxInt64), want: -1},
+		{a: int64(math.MinInt64), b: float64(math.MinInt64), want: 0},
+		{a: int64(math.MinInt64), b: -math.MaxFloat64, want: 1},
+		{a: int64(1<<53 + 1), b: float64(1 << 53), want: 1},
+		{a: uint64(math.MaxUint64), b: float64(math.MaxUint64), want: -1},
+		{a: uint64(0), b: -0.5, want: 1},
+		{a: uint(3), b: 3.25, want: -1},
+		{a: 1, b: math.Inf(1), want: -1},
+		{a: 1, b: math.Inf(-1), want: 1},
+		{a: 1, b: nan, want: -1},
+		{a: uint(1), b: nan, want: -1},
+		{a: nan, b: math.Inf(1), want: 1},
+		{a: nan, b: nan, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(name2(tt.a, tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.want, compareNumbers(reflect.ValueOf(tt.a), reflect.ValueOf(tt.b)))
+			assert.Equal(t, -tt.want, compareNumbers(reflect.ValueOf(tt.b), reflect.ValueOf(tt.a)))
+			assert.Equal(t, tt.want < 0, Is(tt.a).Less(tt.b))
+		})
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Is(3).Less(3.5))
+	assert.True(t, Is(uint8(200)).Greater(-1))
+	assert.True(t, Is(new(int)).Equal(0.0))
+
+	values := []interface{}{2.5, uint(2), -1, float32(0.5)}
+	Sort(values)
+	assert.Equal(t, []interface{}{-1, float32(0.5), uint(2), 2.5}, values)
+
+	// Integers can be promoted to the type of a floating point function.
+	ints := []int{3, 1, 2}
+	By(func(a, b float64) int { return compareFloat(b, a, 1) }).Sort(ints)
+	assert.Equal(t, []int{3, 2, 1}, ints)
+
+	// Floating point values can be converted to the type of an integer function, if they are
+	// exactly representable by it.
+	floats := []float64{3, 1, 2}
+	By(func(a, b int) int { return a - b }).Sort(floats)
+	assert.Equal(t, []float64{1, 2, 3}, floats)
+	// Values are checked before the slice is modified.
+	floats = []float64{2, 1.5, 0.5}
+	assert.PanicsWithValue(t,
+		"value 1.5 at index 1 can't be converted to int without losing precision",
+		func() { By(func(a, b int) int { return a - b }).Sort(floats) })
+	assert.Equal(t, []float64{2, 1.5, 0.5}, floats)
+	assert.PanicsWithValue(t,
+		"value 0.5 can't be converted to int without losing precision",
+		func() { By(func(a, b int) int { return a - b }).Search([]int{1}, 0.5) })
+
+	// Values of other numeric types can be searched and clamped.
+	assert.Equal(t, 1, Search([]int{1, 2, 3}, 2.0))
+	assert.Equal(t, -1, Search([]int{1, 2, 3}, 2.5))
+	assert.Equal(t, 0, Search([]uint{1, 2, 3}, int8(1)))
+	assert.Equal(t, 3, Clamp(3, 1.5, 4.5))
+	assert.Equal(t, 1.5, Clamp(1, 1.5, 4.5))
+	assert.True(t, IsSorted([]interface{}{-1, uint(2), 2.5}))
+}
@@ -3,13 +3,17 @@
 // This package provides functionality to easily define and apply order on values. It works out of
 // the box for most primitive types and their pointer versions, and enable order of any object using
 // (three-way comparison) https://en.wikipedia.org/wiki/Three-way_comparison with a given
-// `func(T, T) int` function, or by implementing the generic interface: `func (T) Compare(T) int`.
+// `func(T, T) int` function, or by implementing the generic interface: `func (T) Compare(T) int`
+// (or `func (T) Cmp(T) int`), or `func (T) Less(T) bool`, or `Before`, `After` and `Equal`
+// methods of the form `func (T) Before(T) bool`.
 //
 // Supported Tasks:
 //
-// * [x] `Sort` / `SortStable` - sort a slice.
+// * [x] `Sort` / `SortStable` / `SortAuto` - sort a slice.
 //
-// * [x] `Search` - binary search for a value in a slice.
+// * [x] `Sorted` / `SortedStable` - get a sorted copy of a slice.
+//
+// * [x] `Search` / `SearchDesc` - binary search for a value in a slice.
 //
 // * [x] `MinMax` - get indices of minimal and maximal values of a slice.
 //
@@ -17,7 +21,155 @@
 //
 // + [x] `Select` - get the K'th greatest value of a slice.
 //
-// * [x] `IsSorted` / `IsStrictSorted` - check if a slice is sorted.
+// * [x] `IsSorted` / `IsStrictSorted` / `IsSortedWithin` / `FirstUnsorted` - check if a slice is sorted.
+//
+// * [x] `SymmetricDifference` - get elements that are present in exactly one of two sorted slices.
+//
+// * [x] `Reverse` / `Rotate` - reverse or rotate a slice in place.
+//
+// * [x] `Diff` - get elements that were added and removed between two sorted slices.
+//
+// * [x] `DiffReaders` - stream the diff of two sorted streams of records.
+//
+// * [x] `Join` - sort-merge join of two sorted slices.
+//
+// * [x] `ValidateSortedStream` / `NewSortedWriter` - check that a stream of records is sorted.
+//
+// * [x] `Compact` - remove consecutive equal elements of a slice.
+//
+// * [x] `Runs` - get the runs of consecutive equal elements of a slice.
+//
+// * [x] `Group` - sort a slice and split it to groups of equal elements.
+//
+// * [x] `Boundaries` - get the indices in which the value of a sorted slice changes.
+//
+// * [x] `Insert` - insert a value into a sorted slice.
+//
+// * [x] `Remove` / `RemoveLast` / `RemoveAll` - remove a value from a sorted slice.
+//
+// * [x] `EstimateSortCost` - estimate the cost of sorting a slice.
+//
+// * [x] `SortedList` - a collection of values that are kept sorted.
+//
+// * [x] `EvictingCache` - keep the N greatest values.
+//
+// * [x] `Set` - a collection of unique values, where equality is defined by the order.
+//
+// * [x] `TimeWindowBuffer` - hold time stamped values within a time window.
+//
+// * [x] `Multiset` - a sorted collection of values with duplicate counts.
+//
+// * [x] `TreeMap` - a map with keys ordered by the order.
+//
+// * [x] `Deltas` / `MaxIncreasingStreak` - analyze the changes between adjacent elements.
+//
+// * [x] `MakeSorted` - repair a nearly sorted slice.
+//
+// * [x] `SkipList` - a sorted collection backed by a skip list.
+//
+// * [x] `Queue` - a priority queue.
+//
+// * [x] `EqualFrequencyBuckets` - get boundaries of equal size buckets of a slice.
+//
+// * [x] `Heap` - get a container/heap adapter over a slice.
+//
+// * [x] `MinMaxHeap` - a double ended priority queue.
+//
+// * [x] `Range` - a range of values, with containment, intersection, union and coverage gaps.
+//
+// * [x] `IsHeap` - check if a slice satisfies the heap property.
+//
+// * [x] `Argsort` - get the indices that sort a slice, without modifying it.
+//
+// * [x] `Migrations` - run versioned migration steps in order, and resume from a cursor.
+//
+// * [x] `SortTogether` - sort parallel slices by a keys slice.
+//
+// * [x] `ByText` - order values by their text representation.
+//
+// * [x] `SortByKeys` - sort a slice by keys that are computed once for each element.
+//
+// * [x] `ByHash` - a deterministic pseudo-random order by a seeded hash of a key.
+//
+// * [x] `Index` - a sorted view for searching a slice without reordering it.
+//
+// * [x] `Ranks` - get the rank of each element of a slice, with a policy for ties.
+//
+// * [x] `Rank` - get the number of elements of a sorted slice that are less than a value.
+//
+// * [x] `Inversions` - count the out of order pairs of a slice.
+//
+// * [x] `Correlation` - get the rank correlation between two orders.
+//
+// * [x] `LongestSortedRun` / `LIS` - find the longest sorted run or increasing subsequence of a slice.
+//
+// * [x] `Direction` - detect the direction in which a slice is sorted.
+//
+// * [x] `NaNFirst` / `NaNLast` - a total order of floating point numbers with NaN values.
+//
+// * [x] `NullString` / `NullInt64` / `NullFloat64` / `NullTime` - order database/sql null types, with nulls first or last.
+//
+// * [x] `Natural` - order strings with numbers by the numeric value of the numbers.
+//
+// * [x] `StringsFold` - order strings ignoring case, and `WithStringsFold` for the package level functions.
+//
+// * [x] `Collate` - order strings by the collation rules of a language.
+//
+// * [x] `StringsStripAccents` - order strings ignoring accents.
+//
+// * [x] `Register` - add comparison functions for types that are not owned.
+//
+// * [x] Configurable names of three-way comparison methods with `WithCompareMethod`.
+//
+// * [x] Less functions of the form `func(T, T) bool` in `By`.
+//
+// * [x] Key functions of the form `func(T) K` in `By`.
+//
+// * [x] Ordering by struct field names with `ByFields`.
+//
+// * [x] `cmd/ordergen` - generate reflection-free comparison and sort functions.
+//
+// * [x] `Deep` - deterministic order of arbitrary nested values.
+//
+// * [x] Arrays `[N]T` as values, compared lexicographically.
+//
+// * [x] Slices `[]T` as values, compared lexicographically.
+//
+// * [x] Maps `map[K]V` as values, compared by their sorted key-value pairs.
+//
+// * [x] `CompareSlices` - lexicographic comparison of two slices.
+//
+// * [x] `EqualSlices` - element-wise equality of two slices.
+//
+// * [x] Slices of interface types, such as `[]interface{}`, ordered by the dynamic type of their elements.
+//
+// * [x] Comparison of numbers of different types, such as `Is(3).Less(3.5)`.
+//
+// * [x] Comparison of strings with byte slices.
+//
+// * [x] `Between` / `In` / `NotIn` - range and membership conditions.
+//
+// * [x] `Clamp` - limit a value to a range.
+//
+// * [x] `EqualWithin` - approximate equality within a tolerance.
+//
+// * [x] `Fns.Equal` / `Fns.Less` / `Fns.Greater` - compare two values.
+//
+// * [x] `MinOf` / `MaxOf` - get the extreme of the given values.
+//
+// * [x] `Interval` - intervals of values with configurable bounds.
+//
+// * [x] `MergeIntervals` - coalesce overlapping and adjacent intervals.
+//
+// * [x] `RangeSet` - a collection of disjoint ranges of values.
+//
+// * [x] `Bucketize` - assign elements to buckets by sorted boundaries.
+//
+// * [x] `MinMaxParallel` and `SelectParallel` - concurrent scans of large slices.
+//
+// * [x] `SortLines` - sort the lines of a reader by parsed keys.
+//
+// * [x] `SortedIter` - lazy sorted iteration without modifying the slice.
 //
 // Types and Values
 //
@@ -33,6 +185,19 @@
 // * `T` and `U` are of the same number kind group (int?, uint?, float?, complex?) and `U`'s bits
 // number is less or equal to `T`'s bits number.
 //
+// * `U` is an unsigned integer type and `T` is a signed integer type with a greater bits number.
+//
+// * `U` is an integer type and `T` is a floating point type. Integers of magnitude greater than 2^53
+// for float64, or 2^24 for float32, are rounded to the nearest representable value.
+//
+// * `U` is a floating point type and `T` is an integer type. Only values that are exactly
+// representable by `T` are converted, other values, such as 1.5, cause a panic. All the elements of
+// a slice are checked before the operation starts, such that the slice is not modified on a panic.
+//
+// The package level functions, such as `Is`, `Sort` and `Search`, compare values of all numeric
+// types by their exact numeric value, without conversions, such that `order.Is(3).Less(3.5)` and
+// `order.Search([]int{1, 2, 3}, 2.0)` finds the value. NaN values are greater than all other values.
+//
 // * `U` and `T` are assignable structs.
 //
 // Read more about this package in this (blog post) https://posener.github.io/order.
@@ -89,18 +254,20 @@
 // 	 // Conditions can also be defined on comparable types:
 // 	 var t, start, end time.Time
 // 	-if (t.After(start) || t.Equal(start)) && t.Before(end) { ... }
-// 	+if isT := order.Is(t); isT.GreaterEqual(start) && isT.Less(end) { ... }
+// 	+if order.Is(t).Between(start, end, order.ClosedOpen) { ... }
 package order
 
 import (
 	"fmt"
 	"reflect"
 	"sort"
+
+	"github.com/posener/order/internal/reflectutil"
 )
 
 // By enables ordering values of type T by a given list of three-way comparison functions of the
 // form `func(T, T) int`. Each function compares two values (`lhs`, `rhs`) of type T, and returns a
-// value `c` of type int as follows:
+// value `c` of type int, or of any other signed integer type, as follows:
 //
 // If lhs >  rhs then c > 0.
 // If lhs == rhs then c = 0.
@@ -110,13 +277,30 @@ import (
 // compared, the first function is evaluated, if the comparison value is not zero, the value is
 // returned. Otherwise, the following function is evaluated until a non-zero value is returned.
 // If all the comparison functions returned zero, the returned value is also zero.
+//
+// The type T of the list is the type of the first function. The other functions may be of different
+// types, as long as T is convertible to their types, for example, a function over `int64` may follow
+// a function over `int32`.
+//
+// A function may also be a less function of the form `func(T, T) bool`, which returns whether lhs
+// is less than rhs, as used by sort.Slice. The three-way comparison is then derived by evaluating
+// it in both directions: two values are equal if none of them is less than the other.
+//
+// A function may also be a key function of the form `func(T) K`, which extracts a key from a value.
+// Values are then ordered by their keys, which are compared as in the package level functions,
+// such as Sort. For example:
+//
+// 	order.By(
+// 		func(p person) string { return p.name },
+// 		func(p person) int { return -p.age },
+// 	)
 func By(fns ...interface{}) Fns {
 	if len(fns) == 0 {
 		panic("Expected at least one comparison function")
 	}
 	cmpFns := make(Fns, 0, len(fns))
 	for i, fn := range fns {
-		cmpFn, err := newFn(reflect.ValueOf(fn))
+		cmpFn, err := newByFn(reflect.ValueOf(fn))
 		if err != nil {
 			panic(fmt.Sprintf("Invalid function %d: %s", i, err))
 		}
@@ -134,48 +318,167 @@ func (fns Fns) Reversed() Fns {
 	for i := range fns {
 		original := fns[i] // Copy.
 		newFns[i] = Fn{
-			fn: func(lhs, rhs reflect.Value) int { return -original.fn(lhs, rhs) },
-			t:  original.t,
+			fn:          func(lhs, rhs reflect.Value) int { return -original.fn(lhs, rhs) },
+			t:           original.t,
+			calibration: original.calibration,
+		}
+		if original.bind != nil {
+			newFns[i].bind = func(lhsT, rhsT reflect.Type) func(lhs, rhs reflect.Value) int {
+				fn := original.bind(lhsT, rhsT)
+				return func(lhs, rhs reflect.Value) int { return -fn(lhs, rhs) }
+			}
 		}
 	}
 	return newFns
 }
 
-// Sort sorts a given slice according to the comparison function.
+// Sort sorts a given slice according to the comparison function. It uses pattern-defeating
+// quicksort, which is not guaranteed to be stable.
 func (fns Fns) Sort(slice interface{}) {
-	sort.Slice(slice, fns.less(reflect.ValueOf(slice)))
+	if fns.sortNatural(slice, false) {
+		return
+	}
+	fns.sortSlice(fns.mustSlice(reflect.ValueOf(slice)))
 }
 
 // SortStable sorts a given slice according to the comparison function, while keeping the original
 // order of equal elements.
 func (fns Fns) SortStable(slice interface{}) {
-	sort.SliceStable(slice, fns.less(reflect.ValueOf(slice)))
+	if fns.sortNatural(slice, true) {
+		return
+	}
+	fns.sortSliceStable(fns.mustSlice(reflect.ValueOf(slice)))
 }
 
-// less return a comparison function for a given slice to be used with sort.Slice and
-// sort.SliceStable.
-func (fns Fns) less(slice reflect.Value) func(i, j int) bool {
-	s := fns.mustSlice(slice)
+// SortTogether sorts the keys slice according to the comparison function, and applies the same
+// reordering to each of the other slices, such that elements at the same index in all the slices
+// stay at the same index. It can be used to sort columnar data, where each column is a slice. The
+// sort is stable, equal keys keep their original order. The other slices can be of any type. It
+// panics if the other slices are not slices, or if they are not of the same length as keys.
+func (fns Fns) SortTogether(keys interface{}, others ...interface{}) {
+	s := fns.mustSlice(reflect.ValueOf(keys))
+	t := together{fns: fns, keys: s}
+	for i, other := range others {
+		o := mustSlice(reflect.ValueOf(other))
+		if o.Len() != s.Len() {
+			panic(fmt.Sprintf("slice %d length %d is different from keys length %d", i, o.Len(), s.Len()))
+		}
+		t.others = append(t.others, o)
+	}
+	sort.Stable(t)
+}
+
+// together implements sort.Interface over a keys slice, and swaps the elements of the other slices
+// with the keys.
+type together struct {
+	fns    Fns
+	keys   reflectutil.Slice
+	others []reflectutil.Slice
+}
+
+func (t together) Len() int { return t.keys.Len() }
+
+func (t together) Less(i, j int) bool { return t.fns.compare(t.keys.Index(i), t.keys.Index(j)) < 0 }
+
+func (t together) Swap(i, j int) {
+	t.keys.Swap(i, j)
+	for _, o := range t.others {
+		o.Swap(i, j)
+	}
+}
+
+// SortByKeys sorts a given slice by keys that are computed from its elements, according to the
+// comparison function of the keys type. The key function is called exactly once for each element,
+// before sorting, which is useful when extracting the key is expensive. The sort is stable, elements
+// with equal keys keep their original order. It panics if a returned key is not of type T.
+func (fns Fns) SortByKeys(slice interface{}, key func(interface{}) interface{}) {
+	s := mustSlice(reflect.ValueOf(slice))
+	k := keyed{fns: fns, s: s, keys: make([]reflect.Value, s.Len())}
+	for i := range k.keys {
+		k.keys[i] = fns.mustValue(reflect.ValueOf(key(s.Index(i).Interface())))
+	}
+	sort.Stable(k)
+}
+
+// keyed implements sort.Interface over a slice with precomputed keys.
+type keyed struct {
+	fns  Fns
+	s    reflectutil.Slice
+	keys []reflect.Value
+}
+
+func (k keyed) Len() int { return len(k.keys) }
+
+func (k keyed) Less(i, j int) bool { return k.fns.compare(k.keys[i], k.keys[j]) < 0 }
 
-	return func(i, j int) bool {
-		return fns.compare(s.Index(i), s.Index(j)) < 0
+func (k keyed) Swap(i, j int) {
+	k.s.Swap(i, j)
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+}
+
+// smallSortLen is the maximal slice length that SortAuto sorts using insertion sort.
+const smallSortLen = 12
+
+// SortAuto sorts a given slice according to the comparison function, choosing the sorting algorithm
+// according to the given slice: small slices are insertion sorted, already sorted slices are left
+// untouched, slices sorted in a decreasing order are reversed, and other slices are sorted with
+// Fns.Sort. Like Fns.Sort, the sort is not guaranteed to be stable.
+func (fns Fns) SortAuto(slice interface{}) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	switch {
+	case s.Len() <= smallSortLen:
+		fns.sortSmallSlice(s)
+	case fns.isSorted(s.Value, false):
+		// Already sorted.
+	case fns.Reversed().isSorted(s.Value, false):
+		reverse(s)
+	default:
+		fns.Sort(s.Interface())
 	}
 }
 
+// Sorted returns a sorted copy of the given slice according to the comparison function, leaving
+// the given slice untouched. The returned value is of the given slice type (or the pointed slice
+// type if a pointer to a slice was given).
+func (fns Fns) Sorted(slice interface{}) interface{} {
+	cp := fns.mustSlice(reflect.ValueOf(slice)).Copy().Interface()
+	fns.Sort(cp)
+	return cp
+}
+
+// SortedStable returns a stable sorted copy of the given slice according to the comparison
+// function, leaving the given slice untouched. See Fns.Sorted.
+func (fns Fns) SortedStable(slice interface{}) interface{} {
+	cp := fns.mustSlice(reflect.ValueOf(slice)).Copy().Interface()
+	fns.SortStable(cp)
+	return cp
+}
+
 // Search searches the given slice for a value. The given slice should be sorted relative to the
 // comparsion function. It returns an index of an element that is equal to the given value. It
-// returns -1 if no element was found that is equal to the given value.
+// returns -1 if no element was found that is equal to the given value. For a slice that is sorted in
+// a decreasing order, use SearchDesc, and for a slice that is sorted in an unknown direction, see
+// Direction.
 func (fns Fns) Search(slice, value interface{}) int {
 	s := fns.mustSlice(reflect.ValueOf(slice))
 	v := fns.mustValue(reflect.ValueOf(value))
+	if cmp, ok := fns.naturalCompareValue(slice, value); ok {
+		return search(s.Len(), cmp)
+	}
+	fns = fns.bind(s.T(), v.Type())
+	return search(s.Len(), func(i int) int { return fns.compare(s.Index(i), v) })
+}
 
-	start, end := 0, s.Len()-1
+// search is a binary search over n sorted elements for an element that compare returns 0 for,
+// where compare(i) compares the i'th element with the searched value.
+func search(n int, compare func(i int) int) int {
+	start, end := 0, n-1
 	if start > end {
 		return -1
 	}
 	for {
 		i := int(uint(start+end) >> 1) // Avoid overflow when computing i.
-		cmp := fns.compare(s.Index(i), v)
+		cmp := compare(i)
 		switch {
 		case cmp == 0: // Found.
 			return i
@@ -189,26 +492,104 @@ func (fns Fns) Search(slice, value interface{}) int {
 	}
 }
 
+// SearchDesc searches the given slice, that is sorted in a decreasing order relative to the
+// comparison function, for a value. It is equivalent to `fns.Reversed().Search(slice, value)`.
+func (fns Fns) SearchDesc(slice, value interface{}) int {
+	return fns.Reversed().Search(slice, value)
+}
+
+// lowerBound returns the index of the first element in the sorted slice that is not less than v, or
+// the slice length if there is no such element.
+func (fns Fns) lowerBound(s reflectutil.Slice, v reflect.Value) int {
+	fns = fns.bind(s.T(), v.Type())
+	return sort.Search(s.Len(), func(i int) bool { return fns.compare(s.Index(i), v) >= 0 })
+}
+
+// upperBound returns the index of the first element in the sorted slice that is greater than v, or
+// the slice length if there is no such element.
+func (fns Fns) upperBound(s reflectutil.Slice, v reflect.Value) int {
+	fns = fns.bind(s.T(), v.Type())
+	return sort.Search(s.Len(), func(i int) bool { return fns.compare(s.Index(i), v) > 0 })
+}
+
 // MinMax returns the indices of the minimal and maximal values in the given slice. It returns
 // values (-1, -1) if the slice is empty. If there are several minimal/maximal values, this function
 // will return the index of the first of them.
 func (fns Fns) MinMax(slice interface{}) (min, max int) {
 	s := fns.mustSlice(reflect.ValueOf(slice))
+	if cmp, ok := fns.naturalCompare(slice); ok {
+		return minMax(s.Len(), cmp)
+	}
+	fns = fns.bindSlice(s)
+	return minMax(s.Len(), func(i, j int) int { return fns.compare(s.Index(i), s.Index(j)) })
+}
 
-	if s.Len() == 0 {
+// minMax returns the indices of the first minimal and maximal elements of n elements, where
+// cmp(i, j) compares the i'th element with the j'th element.
+func minMax(n int, cmp func(i, j int) int) (min, max int) {
+	if n == 0 {
 		return -1, -1
 	}
-	for i := 1; i < s.Len(); i++ {
-		if fns.compare(s.Index(min), s.Index(i)) > 0 {
+	for i := 1; i < n; i++ {
+		if cmp(min, i) > 0 {
 			min = i
 		}
-		if fns.compare(s.Index(max), s.Index(i)) < 0 {
+		if cmp(max, i) < 0 {
 			max = i
 		}
 	}
 	return
 }
 
+// MinOf returns the minimal value of the given values. If there are multiple minimal values, the
+// first of them is returned. It panics if no values are given.
+func (fns Fns) MinOf(values ...interface{}) interface{} {
+	return values[fns.extremeOf(values, -1)]
+}
+
+// MaxOf returns the maximal value of the given values. If there are multiple maximal values, the
+// first of them is returned. It panics if no values are given.
+func (fns Fns) MaxOf(values ...interface{}) interface{} {
+	return values[fns.extremeOf(values, 1)]
+}
+
+// extremeOf returns the index of the first minimal value if sign is -1, or the first maximal value
+// if sign is 1.
+func (fns Fns) extremeOf(values []interface{}, sign int) int {
+	if len(values) == 0 {
+		panic("Expected at least one value")
+	}
+	extreme := 0
+	e := fns.mustValue(reflect.ValueOf(values[0]))
+	for i := 1; i < len(values); i++ {
+		v := fns.mustValue(reflect.ValueOf(values[i]))
+		if fns.compare(v, e)*sign > 0 {
+			extreme, e = i, v
+		}
+	}
+	return extreme
+}
+
+// Clamp returns the value limited to the range [lo, hi]: it returns lo if the value is less than
+// lo, hi if the value is greater than hi, and the value itself otherwise. It panics if lo is
+// greater than hi.
+func (fns Fns) Clamp(value, lo, hi interface{}) interface{} {
+	v := fns.mustValue(reflect.ValueOf(value))
+	l := fns.mustValue(reflect.ValueOf(lo))
+	h := fns.mustValue(reflect.ValueOf(hi))
+	if fns.compare(l, h) > 0 {
+		panic(fmt.Sprintf("invalid clamp range: lo %v is greater than hi %v", lo, hi))
+	}
+	switch {
+	case fns.compare(v, l) < 0:
+		return lo
+	case fns.compare(v, h) > 0:
+		return hi
+	default:
+		return value
+	}
+}
+
 // IsSorted returns whether the slice is in an increasing order, according to the comparsion
 // function.
 //
@@ -226,15 +607,67 @@ func (fns Fns) IsStrictSorted(slice interface{}) bool {
 	return fns.isSorted(reflect.ValueOf(slice), true)
 }
 
+// FirstUnsorted returns the index of the first element of the slice that is out of order according
+// to the comparison function: the first index i such that slice[i] < slice[i-1]. It returns -1 if
+// the slice is sorted.
+func (fns Fns) FirstUnsorted(slice interface{}) int {
+	return fns.firstUnsorted(reflect.ValueOf(slice), false)
+}
+
+// IsSortedWithin returns whether every element of the slice is at most k positions away from its
+// position in the sorted slice, according to the comparison function. Equal elements are assumed to
+// keep their relative order in the sorted slice. For k = 0 it is equivalent to IsSorted.
+func (fns Fns) IsSortedWithin(slice interface{}, k int) bool {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	for sortedI, i := range fns.argsort(s) {
+		if d := i - sortedI; d > k || -d > k {
+			return false
+		}
+	}
+	return true
+}
+
+// Argsort returns the indices of the elements of the slice in a sorted order, without modifying the
+// slice, such that slice[indices[0]] <= slice[indices[1]] <= ... according to the comparison
+// functions. The order is stable: indices of equal elements are kept in increasing order. The
+// returned indices could be used to reorder other slices that are parallel to the given slice.
+func (fns Fns) Argsort(slice interface{}) []int {
+	return fns.argsort(fns.mustSlice(reflect.ValueOf(slice)))
+}
+
+// argsort returns the indices of the elements of the slice in a stable sorted order.
+func (fns Fns) argsort(s reflectutil.Slice) []int {
+	indices := make([]int, s.Len())
+	for i := range indices {
+		indices[i] = i
+	}
+	fns = fns.bindSlice(s)
+	sort.SliceStable(indices, func(i, j int) bool {
+		return fns.compare(s.Index(indices[i]), s.Index(indices[j])) < 0
+	})
+	return indices
+}
+
 // isSorted checks if the slice is sorted.
 func (fns Fns) isSorted(slice reflect.Value, strict bool) bool {
+	return fns.firstUnsorted(slice, strict) < 0
+}
+
+// firstUnsorted returns the index of the first element that is out of order, or -1 if the slice is
+// sorted.
+func (fns Fns) firstUnsorted(slice reflect.Value, strict bool) int {
 	s := fns.mustSlice(slice)
+	cmp, ok := fns.naturalCompare(s.Interface())
+	if !ok {
+		fns = fns.bindSlice(s)
+		cmp = func(i, j int) int { return fns.compare(s.Index(i), s.Index(j)) }
+	}
 
-	for i := s.Len() - 1; i > 0; i-- {
-		cmp := fns.compare(s.Index(i-1), s.Index(i))
+	for i := 1; i < s.Len(); i++ {
+		cmp := cmp(i-1, i)
 		if cmp > 0 || (cmp == 0 && strict) {
-			return false
+			return i
 		}
 	}
-	return true
+	return -1
 }
@@ -1,8 +1,14 @@
 package order
 
 import (
+	"bytes"
 	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -90,6 +96,162 @@ func TestSortStable(t *testing.T) {
 	}
 }
 
+func TestSortAuto(t *testing.T) {
+	t.Parallel()
+
+	increasing := make([]int, 100)
+	for i := range increasing {
+		increasing[i] = i / 2
+	}
+	decreasing := copySlice(increasing)
+	Reverse(decreasing)
+	shuffled := copySlice(increasing)
+	rand.New(rand.NewSource(0)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	tests := []struct {
+		name  string
+		slice []int
+	}{
+		{name: "empty", slice: []int{}},
+		{name: "small", slice: []int{3, 1, 2, 1}},
+		{name: "increasing", slice: increasing},
+		{name: "decreasing", slice: decreasing},
+		{name: "shuffled", slice: shuffled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := copySlice(tt.slice)
+			SortAuto(got)
+			assert.Equal(t, Sorted(tt.slice), got)
+		})
+	}
+}
+
+func TestSortAuto_natural(t *testing.T) {
+	t.Parallel()
+
+	// Count the comparisons of natural functions, which should not be used by the fast paths of Sort.
+	comparisons := 0
+	fns := natural(Fns{{
+		fn: func(lhs, rhs reflect.Value) int {
+			comparisons++
+			return compareNumbers(lhs, rhs)
+		},
+		t: compareableFn(reflect.TypeOf(0))[0].t,
+	}})
+
+	slice := make([]int, 10*radixSortMinLen)
+	rnd := rand.New(rand.NewSource(0))
+	for i := range slice {
+		slice[i] = rnd.Int()
+	}
+	fns.SortAuto(slice)
+	assert.True(t, IsSorted(slice))
+	assert.Less(t, comparisons, 10)
+}
+
+func TestSorted(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{2, 3, 1}
+	got := Sorted(slice)
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.Equal(t, []int{2, 3, 1}, slice)
+
+	// Pointer to a slice returns the pointed slice type.
+	got = Sorted(&slice)
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.Equal(t, []int{2, 3, 1}, slice)
+
+	// Nil slice.
+	assert.Equal(t, []int(nil), Sorted([]int(nil)))
+}
+
+func TestSortedStable(t *testing.T) {
+	t.Parallel()
+
+	intp := func(i int) *int { return &i }
+
+	slice := []*int{intp(2), intp(2), intp(1)}
+	want := []*int{slice[2], slice[0], slice[1]}
+	original := []*int{slice[0], slice[1], slice[2]}
+	got := SortedStable(slice).([]*int)
+	// Check the actual pointers and not the pointer values.
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("Element %d differs", i)
+		}
+		if original[i] != slice[i] {
+			t.Errorf("Original element %d changed", i)
+		}
+	}
+}
+
+func TestArgsort(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{3, 1, 2, 1}
+	assert.Equal(t, []int{1, 3, 2, 0}, Argsort(slice))
+	assert.Equal(t, []int{3, 1, 2, 1}, slice)
+
+	// The indices could be applied to a parallel slice.
+	names := []string{"c", "a1", "b", "a2"}
+	var got []string
+	for _, i := range intFn.Argsort(slice) {
+		got = append(got, names[i])
+	}
+	assert.Equal(t, []string{"a1", "a2", "b", "c"}, got)
+
+	assert.Equal(t, []int{0, 2, 1, 3}, intFn.Reversed().Argsort(slice))
+	assert.Empty(t, Argsort([]int(nil)))
+}
+
+func TestSortTogether(t *testing.T) {
+	t.Parallel()
+
+	keys := []int{3, 1, 2, 1}
+	names := []string{"c", "a1", "b", "a2"}
+	scores := []float64{0.3, 0.1, 0.2, 0.15}
+	SortTogether(keys, names, &scores)
+	assert.Equal(t, []int{1, 1, 2, 3}, keys)
+	assert.Equal(t, []string{"a1", "a2", "b", "c"}, names)
+	assert.Equal(t, []float64{0.1, 0.15, 0.2, 0.3}, scores)
+
+	intFn.Reversed().SortTogether(keys, names)
+	assert.Equal(t, []int{3, 2, 1, 1}, keys)
+	assert.Equal(t, []string{"c", "b", "a1", "a2"}, names)
+
+	assert.Panics(t, func() { intFn.SortTogether(keys, []string{"a"}) })
+	assert.Panics(t, func() { intFn.SortTogether(keys, 1) })
+}
+
+func TestSortByKeys(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	key := func(v interface{}) interface{} {
+		calls++
+		n, err := strconv.Atoi(v.(string))
+		if err != nil {
+			panic(err)
+		}
+		return n
+	}
+
+	slice := []string{"10", "9", "100", "09", "1"}
+	intFn.SortByKeys(slice, key)
+	assert.Equal(t, []string{"1", "9", "09", "10", "100"}, slice)
+	assert.Equal(t, len(slice), calls)
+
+	assert.Panics(t, func() {
+		intFn.SortByKeys([]int{2, 1}, func(v interface{}) interface{} { return "a" })
+	})
+	assert.Panics(t, func() { intFn.SortByKeys(1, key) })
+}
+
 func TestSearch(t *testing.T) {
 	t.Parallel()
 
@@ -165,6 +327,15 @@ func TestSearch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			got := Search(tt.slice, tt.value)
 			assert.Equal(t, tt.want, got)
+
+			// Search the slice in a decreasing order.
+			desc := copySlice(tt.slice)
+			Reverse(desc)
+			wantDesc := -1
+			if tt.want >= 0 {
+				wantDesc = len(desc) - 1 - tt.want
+			}
+			assert.Equal(t, wantDesc, SearchDesc(desc, tt.value))
 		})
 	}
 }
@@ -230,6 +401,53 @@ func TestIsSorted(t *testing.T) {
 	}
 }
 
+func TestFirstUnsorted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  int
+	}{
+		{slice: []int{}, want: -1},
+		{slice: []int{1}, want: -1},
+		{slice: []int{1, 1, 2}, want: -1},
+		{slice: []int{2, 1}, want: 1},
+		{slice: []int{1, 2, 3, 2, 1}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			assert.Equal(t, tt.want, FirstUnsorted(tt.slice))
+		})
+	}
+}
+
+func TestIsSortedWithin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		k     int
+		want  bool
+	}{
+		{slice: []int{}, k: 0, want: true},
+		{slice: []int{1, 2, 3}, k: 0, want: true},
+		{slice: []int{2, 1, 3}, k: 0, want: false},
+		{slice: []int{2, 1, 3}, k: 1, want: true},
+		{slice: []int{3, 1, 2}, k: 1, want: false},
+		{slice: []int{3, 1, 2}, k: 2, want: true},
+		{slice: []int{1, 1, 0, 1}, k: 1, want: false},
+		{slice: []int{1, 1, 0, 1}, k: 2, want: true},
+		{slice: []int{2, 1, 4, 3, 6, 5}, k: 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%d", tt.slice, tt.k), func(t *testing.T) {
+			assert.Equal(t, tt.want, IsSortedWithin(tt.slice, tt.k))
+		})
+	}
+}
+
 func TestMinMax(t *testing.T) {
 	t.Parallel()
 
@@ -274,6 +492,177 @@ func TestMinMax(t *testing.T) {
 	}
 }
 
+func TestMinOfMaxOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values   []interface{}
+		min, max interface{}
+	}{
+		{values: []interface{}{1}, min: 1, max: 1},
+		{values: []interface{}{2, 1, 3}, min: 1, max: 3},
+		{values: []interface{}{3, 3, 1, 1}, min: 1, max: 3},
+		{values: []interface{}{1.5, 2, uint(1)}, min: uint(1), max: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.values), func(t *testing.T) {
+			assert.Equal(t, tt.min, MinOf(tt.values...))
+			assert.Equal(t, tt.max, MaxOf(tt.values...))
+		})
+	}
+
+	// The first of equal values is returned.
+	mod10 := By(func(a, b int) int { return a%10 - b%10 })
+	assert.Equal(t, 11, mod10.MinOf(5, 11, 21))
+	assert.Equal(t, 15, mod10.MaxOf(15, 5, 1))
+
+	assert.Panics(t, func() { MinOf() })
+	assert.Panics(t, func() { intFn.MaxOf() })
+	assert.Panics(t, func() { intFn.MaxOf(1, true) })
+}
+
+func TestClamp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value, want int
+	}{
+		{value: 0, want: 1},
+		{value: 1, want: 1},
+		{value: 2, want: 2},
+		{value: 3, want: 3},
+		{value: 4, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.value), func(t *testing.T) {
+			assert.Equal(t, tt.want, Clamp(tt.value, 1, 3))
+			assert.Equal(t, tt.want, intFn.Clamp(tt.value, 1, 3))
+		})
+	}
+
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	assert.Equal(t, t1, Clamp(t1.Add(time.Minute), t0, t1))
+
+	assert.Equal(t, 2, Clamp(2, 2, 2))
+	assert.Panics(t, func() { Clamp(2, 3, 1) })
+	assert.Panics(t, func() { intFn.Clamp(true, 1, 3) })
+	assert.Panics(t, func() { intFn.Clamp(2, 1, "3") })
+}
+
+func TestBy_signedReturnTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fns  Fns
+	}{
+		{name: "int8", fns: By(func(a, b int) int8 { return int8(a - b) })},
+		{name: "int32", fns: By(func(a, b int) int32 { return int32(a - b) })},
+		{
+			name: "int64 overflowing int32",
+			fns: By(func(a, b int) int64 {
+				return (int64(a) - int64(b)) << 40
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.fns.Is(1).Less(2))
+			assert.True(t, tt.fns.Is(2).Greater(1))
+			assert.True(t, tt.fns.Is(1).Equal(1))
+		})
+	}
+}
+
+func TestBy_less(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		name string
+		age  int
+	}
+	fns := By(
+		func(a, b person) bool { return a.age < b.age },
+		func(a, b person) int { return strings.Compare(a.name, b.name) },
+	)
+
+	assert.True(t, fns.Is(person{"b", 1}).Less(person{"a", 2}))
+	assert.True(t, fns.Is(person{"b", 2}).Greater(person{"a", 2}))
+	assert.True(t, fns.Is(person{"a", 2}).Equal(person{"a", 2}))
+
+	got := []person{{"c", 2}, {"b", 1}, {"a", 2}}
+	fns.Sort(got)
+	assert.Equal(t, []person{{"b", 1}, {"a", 2}, {"c", 2}}, got)
+}
+
+// TestBy_direct is not parallel, since testing.AllocsPerRun can't be called in parallel tests.
+func TestBy_direct(t *testing.T) {
+	tests := []struct {
+		name     string
+		fns      Fns
+		lhs, rhs interface{}
+		want     int
+	}{
+		{name: "int", fns: By(func(a, b int) int { return a - b }), lhs: 1, rhs: 2, want: -1},
+		{name: "int64", fns: By(func(a, b int64) int { return int(a - b) }), lhs: int64(3), rhs: int64(2), want: 1},
+		{name: "float64", fns: By(func(a, b float64) bool { return a < b }), lhs: 1.5, rhs: 1.5, want: 0},
+		{name: "string", fns: By(strings.Compare), lhs: "a", rhs: "b", want: -1},
+		{name: "string less", fns: By(func(a, b string) bool { return a < b }), lhs: "b", rhs: "a", want: 1},
+		{name: "bytes", fns: By(bytes.Compare), lhs: []byte("b"), rhs: []byte("a"), want: 1},
+		{name: "bool", fns: By(compareBool), lhs: false, rhs: true, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lhs, rhs := reflect.ValueOf(tt.lhs), reflect.ValueOf(tt.rhs)
+			assert.Equal(t, tt.want, tt.fns.compare(lhs, rhs))
+			// Built-in functions are called without reflect.Value.Call, which allocates.
+			allocs := testing.AllocsPerRun(10, func() { tt.fns.compare(lhs, rhs) })
+			assert.Zero(t, allocs)
+		})
+	}
+}
+
+func TestBy_key(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		name string
+		age  int
+	}
+	fns := By(
+		func(p person) string { return p.name },
+		func(p *person) int { return -p.age },
+	)
+
+	assert.True(t, fns.Is(person{"a", 2}).Less(person{"b", 1}))
+	assert.True(t, fns.Is(person{"a", 1}).Greater(person{"a", 2}))
+	assert.True(t, fns.Is(person{"a", 2}).Equal(person{"a", 2}))
+
+	got := []person{{"b", 1}, {"a", 1}, {"a", 2}}
+	fns.Sort(got)
+	assert.Equal(t, []person{{"a", 2}, {"a", 1}, {"b", 1}}, got)
+}
+
+func TestBy_convertibleTypes(t *testing.T) {
+	t.Parallel()
+
+	type myInt int
+	fns := By(
+		func(a, b int32) int { return int(a%2 - b%2) },
+		func(a, b int64) int { return int(a - b) },
+		func(a, b myInt) int { return 0 },
+	)
+
+	got := []int32{4, 3, 2, 1}
+	fns.Sort(got)
+	assert.Equal(t, []int32{2, 4, 1, 3}, got)
+}
+
 func TestBy_invalidFn(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -289,8 +678,28 @@ func TestBy_invalidFn(t *testing.T) {
 			fns:  []interface{}{true},
 		},
 		{
-			name: "1 arg",
-			fns:  []interface{}{func(a int) int { return 0 }},
+			name: "1 arg without return values",
+			fns:  []interface{}{func(a int) {}},
+		},
+		{
+			name: "1 arg with 2 return values",
+			fns:  []interface{}{func(a int) (int, int) { return 0, 0 }},
+		},
+		{
+			name: "1 arg with key type without order",
+			fns:  []interface{}{func(a int) struct{} { return struct{}{} }},
+		},
+		{
+			name: "1 variadic arg",
+			fns:  []interface{}{func(a ...int) int { return 0 }},
+		},
+		{
+			name: "variadic args",
+			fns:  []interface{}{func(a int, b ...int) int { return 0 }},
+		},
+		{
+			name: "1 arg invalid type",
+			fns:  []interface{}{func(a func()) int { return 0 }},
 		},
 		{
 			name: "3 arg",
@@ -322,7 +731,18 @@ func TestBy_invalidFn(t *testing.T) {
 		},
 		{
 			name: "invalid return value type",
-			fns:  []interface{}{func(a, b int) bool { return false }},
+			fns:  []interface{}{func(a, b int) string { return "" }},
+		},
+		{
+			name: "unsigned return value type",
+			fns:  []interface{}{func(a, b int) uint { return 0 }},
+		},
+		{
+			name: "functions type not convertible",
+			fns: []interface{}{
+				func(a, b int64) int { return 0 },
+				func(a, b int32) int { return 0 },
+			},
 		},
 		{
 			name: "functions type mismatch",
@@ -346,11 +766,51 @@ func TestInvalidArgs(t *testing.T) {
 	fns := []func(v interface{}){
 		func(v interface{}) { intFn.Sort(v) },
 		func(v interface{}) { intFn.SortStable(v) },
+		func(v interface{}) { intFn.SortAuto(v) },
+		func(v interface{}) { intFn.Sorted(v) },
+		func(v interface{}) { intFn.SortedStable(v) },
 		func(v interface{}) { intFn.Search(v, 1) },
+		func(v interface{}) { intFn.SearchDesc(v, 1) },
 		func(v interface{}) { intFn.IsSorted(v) },
 		func(v interface{}) { intFn.IsStrictSorted(v) },
+		func(v interface{}) { intFn.IsSortedWithin(v, 1) },
 		func(v interface{}) { intFn.MinMax(v) },
 		func(v interface{}) { intFn.Select(v, 0) },
+		func(v interface{}) { intFn.EqualFrequencyBuckets(v, 2) },
+		func(v interface{}) { intFn.Heap(v) },
+		func(v interface{}) { intFn.IsHeap(v) },
+		func(v interface{}) { intFn.Argsort(v) },
+		func(v interface{}) { intFn.SortTogether(v) },
+		func(v interface{}) { intFn.Ranks(v, TiesAverage) },
+		func(v interface{}) { intFn.Rank(v, 1) },
+		func(v interface{}) { intFn.Inversions(v) },
+		func(v interface{}) { intFn.LongestSortedRun(v) },
+		func(v interface{}) { intFn.LIS(v) },
+		func(v interface{}) { intFn.FirstUnsorted(v) },
+		func(v interface{}) { intFn.Direction(v) },
+		func(v interface{}) { intFn.CompareSlices(v, []int{}) },
+		func(v interface{}) { intFn.CompareSlices([]int{}, v) },
+		func(v interface{}) { intFn.EqualSlices(v, []int{}) },
+		func(v interface{}) { intFn.EqualSlices([]int{}, v) },
+		func(v interface{}) { intFn.Bucketize(v, []int{}) },
+		func(v interface{}) { intFn.Bucketize([]int{}, v) },
+		func(v interface{}) { intFn.MinMaxParallel(v, 0) },
+		func(v interface{}) { intFn.SelectParallel(v, 0, 0) },
+		func(v interface{}) { intFn.SortedIter(v) },
+		func(v interface{}) { intFn.Index(v) },
+		func(v interface{}) { intFn.SymmetricDifference(v, v) },
+		func(v interface{}) { intFn.Diff(v, v) },
+		func(v interface{}) { intFn.Join(v, v, func(i, j int) {}) },
+		func(v interface{}) { intFn.Compact(v) },
+		func(v interface{}) { intFn.Runs(v) },
+		func(v interface{}) { intFn.Boundaries(v) },
+		func(v interface{}) { intFn.Insert(v, 1) },
+		func(v interface{}) { intFn.Remove(v, 1) },
+		func(v interface{}) { intFn.RemoveLast(v, 1) },
+		func(v interface{}) { intFn.RemoveAll(v, 1) },
+		func(v interface{}) { intFn.Deltas(v) },
+		func(v interface{}) { intFn.MaxIncreasingStreak(v) },
+		func(v interface{}) { intFn.Group(v, 1) },
 	}
 
 	for _, fn := range fns {
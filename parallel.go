@@ -0,0 +1,144 @@
+package order
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// parallelMinChunk is the minimal number of elements that each worker of a parallel operation
+// scans, since for smaller slices the goroutines overhead outweighs the parallel speedup.
+const parallelMinChunk = 4096
+
+// MinMaxParallel returns the indices of the minimal and maximal values in the given slice, as
+// MinMax, while scanning chunks of the slice concurrently by the given number of workers. If
+// workers is not positive, runtime.GOMAXPROCS workers are used. Small slices are scanned by a
+// single worker. The comparison functions should be safe for concurrent use.
+func (fns Fns) MinMaxParallel(slice interface{}, workers int) (min, max int) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	w := parallelWorkers(s.Len(), workers)
+	if w <= 1 {
+		return fns.MinMax(slice)
+	}
+	cmp, ok := fns.naturalCompare(slice)
+	if !ok {
+		fns = fns.bindSlice(s)
+		cmp = func(i, j int) int { return fns.compare(s.Index(i), s.Index(j)) }
+	}
+
+	mins, maxs := make([]int, w), make([]int, w)
+	chunks(s.Len(), w, func(c, start, end int) {
+		min, max := minMax(end-start, func(i, j int) int { return cmp(start+i, start+j) })
+		mins[c], maxs[c] = start+min, start+max
+	})
+
+	// Merge the chunks results in order, such that the first of equal values is returned.
+	min, max = mins[0], maxs[0]
+	for c := 1; c < w; c++ {
+		if cmp(min, mins[c]) > 0 {
+			min = mins[c]
+		}
+		if cmp(max, maxs[c]) < 0 {
+			max = maxs[c]
+		}
+	}
+	return
+}
+
+// SelectParallel applies select-k algorithm on the given slice and k index, as Select, while
+// partitioning chunks of large slices concurrently by the given number of workers. If workers is
+// not positive, runtime.GOMAXPROCS workers are used. The comparison functions should be safe for
+// concurrent use. This function will panic if k is out of the bounds of slice.
+func (fns Fns) SelectParallel(slice interface{}, k, workers int) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	fns.bindSlice(s).selectK(s, k, workers)
+}
+
+// partitionParallel partitions the slice as partition, with the given number of workers. Each
+// worker partitions a chunk of the slice, and then the elements that are smaller than the pivot and
+// the elements that are greater than or equal to the pivot, which are in the wrong side of the
+// partitioned slice, are swapped with each other.
+func (fns Fns) partitionParallel(s reflectutil.Slice, p, w int) int {
+	n := s.Len()
+
+	// Put the pivot at the end of the slice, and partition the rest of the slice.
+	s.Swap(p, n-1)
+	pivot := s.Index(n - 1)
+	body := s.Slice(0, n-1)
+
+	starts, less := make([]int, w), make([]int, w)
+	chunks(body.Len(), w, func(c, start, end int) {
+		starts[c] = start
+		less[c] = fns.partitionPivot(body.Slice(start, end).Independent(), pivot)
+	})
+
+	// The partition point is the total number of elements that are smaller than the pivot.
+	cursor := 0
+	for _, l := range less {
+		cursor += l
+	}
+
+	// Collect the ranges of smaller elements after the cursor, and of greater or equal elements
+	// before the cursor. They have the same total length.
+	var smaller, greater [][2]int
+	for c := range starts {
+		end := body.Len()
+		if c+1 < w {
+			end = starts[c+1]
+		}
+		mid := starts[c] + less[c]
+		if lo := maxInt(starts[c], cursor); lo < mid {
+			smaller = append(smaller, [2]int{lo, mid})
+		}
+		if hi := minInt(end, cursor); mid < hi {
+			greater = append(greater, [2]int{mid, hi})
+		}
+	}
+	for len(smaller) > 0 {
+		i, j := &smaller[0], &greater[0]
+		s.Swap(i[0], j[0])
+		if i[0]++; i[0] == i[1] {
+			smaller = smaller[1:]
+		}
+		if j[0]++; j[0] == j[1] {
+			greater = greater[1:]
+		}
+	}
+
+	// Move the pivot value back to the cursor location.
+	s.Swap(cursor, n-1)
+
+	return cursor
+}
+
+// parallelWorkers returns the number of workers that should scan n elements, given the requested
+// number of workers.
+func parallelWorkers(n, workers int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return minInt(workers, n/parallelMinChunk)
+}
+
+// chunks splits n elements to w consecutive chunks of about the same size, and calls f concurrently
+// with the index of each chunk and its range. It returns after all the calls returned.
+func chunks(n, w int, f func(c, start, end int)) {
+	var wg sync.WaitGroup
+	wg.Add(w)
+	for c := 0; c < w; c++ {
+		go func(c int) {
+			defer wg.Done()
+			f(c, c*n/w, (c+1)*n/w)
+		}(c)
+	}
+	wg.Wait()
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,83 @@
+package order
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxParallel(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	slice := make([]int, 10*parallelMinChunk)
+	for i := range slice {
+		slice[i] = rnd.Intn(100)
+	}
+
+	tests := []struct {
+		name  string
+		fns   Fns
+		slice interface{}
+	}{
+		{name: "natural", fns: compareableFn(reflect.TypeOf(0)), slice: slice},
+		{name: "reflection", fns: intFn, slice: slice},
+		{name: "reversed", fns: intFn.Reversed(), slice: slice},
+		{name: "small", fns: intFn, slice: slice[:100]},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			wantMin, wantMax := tt.fns.MinMax(tt.slice)
+			for _, workers := range []int{0, 1, 3, 8} {
+				min, max := tt.fns.MinMaxParallel(tt.slice, workers)
+				assert.Equal(t, wantMin, min, "workers: %d", workers)
+				assert.Equal(t, wantMax, max, "workers: %d", workers)
+			}
+		})
+	}
+
+	min, max := MinMaxParallel([]int{}, 0)
+	assert.Equal(t, -1, min)
+	assert.Equal(t, -1, max)
+}
+
+func TestSelectParallel(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	// Many duplicates are only tested with a small slice, since Select is quadratic for them.
+	for _, tt := range []struct{ n, max int }{
+		{n: 100, max: 3},
+		{n: 100, max: 100},
+		{n: 10 * parallelMinChunk, max: 10 * parallelMinChunk},
+	} {
+		slice := make([]int, tt.n)
+		for i := range slice {
+			slice[i] = rnd.Intn(tt.max)
+		}
+		sorted := append([]int(nil), slice...)
+		sort.Ints(sorted)
+
+		for _, k := range []int{0, tt.n / 3, tt.n - 1} {
+			for _, workers := range []int{0, 3} {
+				got := append([]int(nil), slice...)
+				intFn.SelectParallel(got, k, workers)
+				assert.Equal(t, sorted[k], got[k], "n: %d, k: %d, workers: %d", tt.n, k, workers)
+				for i := range got {
+					if (i < k && got[i] > got[k]) || (i > k && got[i] < got[k]) {
+						t.Fatalf("not partitioned at %d, n: %d, k: %d, workers: %d", i, tt.n, k, workers)
+					}
+				}
+			}
+		}
+	}
+
+	assert.Panics(t, func() { SelectParallel([]int{1}, 1, 0) })
+}
@@ -0,0 +1,540 @@
+package order
+
+import (
+	"math/bits"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// The sorting algorithms in this file are adapted from the Go standard library sort package, to
+// sort a reflectutil.Slice directly with the comparison functions, without the indirection of
+// sort.Slice.
+//
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// sortSlice sorts the slice using pattern-defeating quicksort.
+func (fns Fns) sortSlice(s reflectutil.Slice) {
+	n := s.Len()
+	newSorter(fns, s).pdqsort(0, n, bits.Len(uint(n)))
+}
+
+// sortSliceStable sorts the slice using insertion sort and symmetric merges, while keeping the
+// original order of equal elements.
+func (fns Fns) sortSliceStable(s reflectutil.Slice) {
+	newSorter(fns, s).stable(s.Len())
+}
+
+// sorter sorts a slice according to comparison functions.
+type sorter struct {
+	s   reflectutil.Slice
+	fns Fns
+}
+
+func newSorter(fns Fns, s reflectutil.Slice) sorter {
+	return sorter{s: s, fns: fns.bindSlice(s)}
+}
+
+func (d sorter) less(i, j int) bool {
+	return d.fns.compare(d.s.Index(i), d.s.Index(j)) < 0
+}
+
+func (d sorter) swap(i, j int) {
+	d.s.Swap(i, j)
+}
+
+type sortedHint int // hint for pdqsort when choosing the pivot
+
+const (
+	unknownHint sortedHint = iota
+	increasingHint
+	decreasingHint
+)
+
+// xorshift paper: https://www.jstatsoft.org/article/view/v008i14/xorshift.pdf
+type xorshift uint64
+
+func (r *xorshift) Next() uint64 {
+	*r ^= *r << 13
+	*r ^= *r >> 7
+	*r ^= *r << 17
+	return uint64(*r)
+}
+
+func nextPowerOfTwo(length int) uint {
+	shift := uint(bits.Len(uint(length)))
+	return uint(1 << shift)
+}
+
+// insertionSort sorts data[a:b] using insertion sort.
+func (d sorter) insertionSort(a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && d.less(j, j-1); j-- {
+			d.swap(j, j-1)
+		}
+	}
+}
+
+// siftDown implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func (d sorter) siftDown(lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && d.less(first+child, first+child+1) {
+			child++
+		}
+		if !d.less(first+root, first+child) {
+			return
+		}
+		d.swap(first+root, first+child)
+		root = child
+	}
+}
+
+func (d sorter) heapSort(a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		d.siftDown(i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		d.swap(first, first+i)
+		d.siftDown(lo, i, first)
+	}
+}
+
+// pdqsort sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func (d sorter) pdqsort(a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			d.insertionSort(a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			d.heapSort(a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			d.breakPatterns(a, b)
+			limit--
+		}
+
+		pivot, hint := d.choosePivot(a, b)
+		if hint == decreasingHint {
+			d.reverseRange(a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if d.partialInsertionSort(a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !d.less(a-1, pivot) {
+			mid := d.partitionEqual(a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := d.partition(a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			d.pdqsort(a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			d.pdqsort(mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func (d sorter) partition(a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	d.swap(a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && d.less(i, a) {
+		i++
+	}
+	for i <= j && !d.less(j, a) {
+		j--
+	}
+	if i > j {
+		d.swap(j, a)
+		return j, true
+	}
+	d.swap(i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && d.less(i, a) {
+			i++
+		}
+		for i <= j && !d.less(j, a) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		d.swap(i, j)
+		i++
+		j--
+	}
+	d.swap(j, a)
+	return j, false
+}
+
+// partitionEqual partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func (d sorter) partitionEqual(a, b, pivot int) (newpivot int) {
+	d.swap(a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !d.less(a, i) {
+			i++
+		}
+		for i <= j && d.less(a, j) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		d.swap(i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort partially sorts a slice, returns true if the slice is sorted at the end.
+func (d sorter) partialInsertionSort(a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !d.less(i, i-1) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		d.swap(i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !d.less(j, j-1) {
+					break
+				}
+				d.swap(j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !d.less(j, j-1) {
+					break
+				}
+				d.swap(j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func (d sorter) breakPatterns(a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := xorshift(length)
+		modulus := nextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			d.swap(idx, a+other)
+		}
+	}
+}
+
+// choosePivot chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func (d sorter) choosePivot(a, b int) (pivot int, hint sortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = d.medianAdjacent(i, &swaps)
+			j = d.medianAdjacent(j, &swaps)
+			k = d.medianAdjacent(k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = d.median(i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+// order2 returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func (d sorter) order2(a, b int, swaps *int) (int, int) {
+	if d.less(b, a) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func (d sorter) median(a, b, c int, swaps *int) int {
+	a, b = d.order2(a, b, swaps)
+	b, c = d.order2(b, c, swaps)
+	a, b = d.order2(a, b, swaps)
+	return b
+}
+
+// medianAdjacent finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func (d sorter) medianAdjacent(a int, swaps *int) int {
+	return d.median(a-1, a, a+1, swaps)
+}
+
+func (d sorter) reverseRange(a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		d.swap(i, j)
+		i++
+		j--
+	}
+}
+
+func (d sorter) swapRange(a, b, n int) {
+	for i := 0; i < n; i++ {
+		d.swap(a+i, b+i)
+	}
+}
+
+func (d sorter) stable(n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		d.insertionSort(a, b)
+		a = b
+		b += blockSize
+	}
+	d.insertionSort(a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			d.symMerge(a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			d.symMerge(a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func (d sorter) symMerge(a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if d.less(h, a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			d.swap(k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !d.less(m, h) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			d.swap(k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !d.less(p-c, c) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		d.rotate(start, m, end)
+	}
+	if a < start && start < mid {
+		d.symMerge(a, start, mid)
+	}
+	if mid < end && end < b {
+		d.symMerge(mid, end, b)
+	}
+}
+
+// rotate rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func (d sorter) rotate(a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			d.swapRange(m-i, m, j)
+			i -= j
+		} else {
+			d.swapRange(m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	d.swapRange(m-i, m, i)
+}
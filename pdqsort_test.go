@@ -0,0 +1,85 @@
+package order
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortSlice(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	random := func(n, max int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = rnd.Intn(max)
+		}
+		return s
+	}
+	sorted := func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		return s
+	}
+	reversed := func(n int) []int {
+		s := sorted(n)
+		sort.Sort(sort.Reverse(sort.IntSlice(s)))
+		return s
+	}
+
+	tests := []struct {
+		name  string
+		slice []int
+	}{
+		{name: "empty", slice: []int{}},
+		{name: "small", slice: random(10, 100)},
+		{name: "random", slice: random(1000, 1000)},
+		{name: "duplicates", slice: random(1000, 3)},
+		{name: "sorted", slice: sorted(1000)},
+		{name: "reversed", slice: reversed(1000)},
+		{name: "nearly sorted", slice: append(sorted(1000), 0)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := append([]int(nil), tt.slice...)
+			sort.Ints(want)
+
+			got := append([]int(nil), tt.slice...)
+			intFn.Sort(got)
+			assert.Equal(t, want, got)
+
+			got = append([]int(nil), tt.slice...)
+			intFn.SortStable(got)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestSortSliceStable(t *testing.T) {
+	t.Parallel()
+
+	type pair struct{ key, index int }
+
+	rnd := rand.New(rand.NewSource(1))
+	pairs := make([]pair, 1000)
+	for i := range pairs {
+		pairs[i] = pair{key: rnd.Intn(10), index: i}
+	}
+
+	By(func(a, b pair) int { return a.key - b.key }).SortStable(pairs)
+	assert.True(t, sort.SliceIsSorted(pairs, func(i, j int) bool {
+		if pairs[i].key != pairs[j].key {
+			return pairs[i].key < pairs[j].key
+		}
+		return pairs[i].index < pairs[j].index
+	}))
+}
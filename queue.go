@@ -0,0 +1,65 @@
+package order
+
+import (
+	"container/heap"
+	"reflect"
+)
+
+// Queue is a priority queue of values, where the smallest value according to comparison functions
+// is popped first. Equal values are popped in an unspecified order. A Queue is not safe for
+// concurrent use.
+type Queue struct {
+	h queueHeap
+}
+
+// NewQueue returns an empty Queue of values of type T, ordered by the given comparison functions.
+// To pop the greatest value first, use `NewQueue(fns.Reversed())`.
+func NewQueue(fns Fns) *Queue {
+	return &Queue{h: queueHeap{fns: fns}}
+}
+
+// Push pushes a value to the queue. It panics if the value is not of type T.
+func (q *Queue) Push(value interface{}) {
+	q.h.fns.mustValue(reflect.ValueOf(value))
+	heap.Push(&q.h, value)
+}
+
+// Pop removes and returns the smallest value in the queue. It panics if the queue is empty.
+func (q *Queue) Pop() interface{} {
+	return heap.Pop(&q.h)
+}
+
+// Peek returns the smallest value in the queue without removing it. It panics if the queue is empty.
+func (q *Queue) Peek() interface{} {
+	return q.h.values[0]
+}
+
+// Len returns the number of values in the queue.
+func (q *Queue) Len() int {
+	return q.h.Len()
+}
+
+// queueHeap implements heap.Interface for the Queue.
+type queueHeap struct {
+	fns    Fns
+	values []interface{}
+}
+
+func (h *queueHeap) Len() int { return len(h.values) }
+
+func (h *queueHeap) Less(i, j int) bool {
+	return h.fns.compare(reflect.ValueOf(h.values[i]), reflect.ValueOf(h.values[j])) < 0
+}
+
+func (h *queueHeap) Swap(i, j int) { h.values[i], h.values[j] = h.values[j], h.values[i] }
+
+func (h *queueHeap) Push(x interface{}) { h.values = append(h.values, x) }
+
+func (h *queueHeap) Pop() interface{} {
+	n := len(h.values) - 1
+	x := h.values[n]
+	// Allow garbage collection of the popped value.
+	h.values[n] = nil
+	h.values = h.values[:n]
+	return x
+}
@@ -0,0 +1,55 @@
+package order
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue(intFn)
+	assert.Equal(t, 0, q.Len())
+	assert.Panics(t, func() { q.Pop() })
+	assert.Panics(t, func() { q.Peek() })
+
+	for _, v := range []int{3, 1, 4, 1, 5} {
+		q.Push(v)
+	}
+	assert.Equal(t, 5, q.Len())
+	assert.Equal(t, 1, q.Peek())
+
+	var got []interface{}
+	for q.Len() > 0 {
+		got = append(got, q.Pop())
+	}
+	assert.Equal(t, []interface{}{1, 1, 3, 4, 5}, got)
+}
+
+func TestQueue_reversed(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(0))
+	q := NewQueue(intFn.Reversed())
+	want := make([]int, 100)
+	for i := range want {
+		want[i] = rnd.Intn(50)
+		q.Push(want[i])
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(want)))
+
+	for _, v := range want {
+		assert.Equal(t, v, q.Pop())
+	}
+}
+
+func TestQueue_invalidValue(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue(intFn)
+	assert.Panics(t, func() { q.Push("1") })
+	assert.Equal(t, 0, q.Len())
+}
@@ -0,0 +1,171 @@
+package order
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// radixSortMinLen is the minimal slice length that is sorted using radix sort, since for smaller
+// slices the radix sort overhead outweighs its speedup.
+const radixSortMinLen = 256
+
+// radixSortMaxDepth is the maximal string byte depth that radix sort sorts by, deeper common
+// prefixes are sorted by comparisons.
+const radixSortMaxDepth = 32
+
+// sortRadix sorts the given slice using radix sort, if it is a slice of an integer kind or of a
+// string kind, and the functions are its natural order. Since equal integers and equal strings are
+// identical, the elements are sorted by extracting their values, sorting the values without
+// reflection, and writing the sorted values back, which is also stable. It returns false if the
+// slice should be sorted by comparisons.
+func (fns Fns) sortRadix(slice interface{}) bool {
+	if !fns.isNatural() {
+		return false
+	}
+	s, err := reflectutil.NewSlice(reflect.ValueOf(slice))
+	if err != nil || s.Len() < radixSortMinLen || !fns.check(s.T()) || !s.Index(0).CanSet() {
+		return false
+	}
+	switch numberKindOf(s.T().Kind()) {
+	case intNumber:
+		sortRadixInts(s)
+		return true
+	case uintNumber:
+		sortRadixUints(s)
+		return true
+	}
+	if s.T().Kind() == reflect.String {
+		sortRadixStrings(s)
+		return true
+	}
+	return false
+}
+
+// sortRadixInts sorts a slice of a signed integer kind. The values are mapped to unsigned keys by
+// flipping their sign bit, such that the order of the keys is the order of the values.
+func sortRadixInts(s reflectutil.Slice) {
+	bits := uint(s.T().Bits())
+	signBit := uint64(1) << (bits - 1)
+	mask := ^uint64(0) >> (64 - bits)
+
+	keys := make([]uint64, s.Len())
+	for i := range keys {
+		keys[i] = (uint64(s.Index(i).Int()) ^ signBit) & mask
+	}
+	radixSortUint64(keys, int(bits/8))
+	for i, key := range keys {
+		// Flip back the sign bit, and extend the sign to the 64 bits of int64.
+		s.Index(i).SetInt(int64((key^signBit)<<(64-bits)) >> (64 - bits))
+	}
+}
+
+// sortRadixUints sorts a slice of an unsigned integer kind.
+func sortRadixUints(s reflectutil.Slice) {
+	keys := make([]uint64, s.Len())
+	for i := range keys {
+		keys[i] = s.Index(i).Uint()
+	}
+	radixSortUint64(keys, s.T().Bits()/8)
+	for i, key := range keys {
+		s.Index(i).SetUint(key)
+	}
+}
+
+// sortRadixStrings sorts a slice of a string kind.
+func sortRadixStrings(s reflectutil.Slice) {
+	values := make([]string, s.Len())
+	for i := range values {
+		values[i] = s.Index(i).String()
+	}
+	radixSortStrings(values, make([]string, len(values)), 0)
+	for i, v := range values {
+		s.Index(i).SetString(v)
+	}
+}
+
+// radixSortUint64 sorts keys that have the given number of bytes, using least significant digit
+// radix sort, where each digit is a byte.
+func radixSortUint64(keys []uint64, bytes int) {
+	src, dst := keys, make([]uint64, len(keys))
+	for b := 0; b < bytes; b++ {
+		shift := uint(8 * b)
+		var count [256]int
+		for _, key := range src {
+			count[byte(key>>shift)]++
+		}
+		if count[byte(src[0]>>shift)] == len(src) {
+			// All the keys have the same digit, the pass won't change their order.
+			continue
+		}
+		pos := 0
+		for d, c := range count {
+			count[d] = pos
+			pos += c
+		}
+		for _, key := range src {
+			d := byte(key >> shift)
+			dst[count[d]] = key
+			count[d]++
+		}
+		src, dst = dst, src
+	}
+	copy(keys, src)
+}
+
+// radixSortStrings sorts strings that have the same prefix of the given depth, using most
+// significant digit radix sort, where each digit is a byte. The tmp slice should be of the same
+// length as values. The recursion is bounded by radixSortMaxDepth, such that strings with long
+// common prefixes don't exhaust the stack.
+func radixSortStrings(values, tmp []string, depth int) {
+	for {
+		if len(values) < radixSortMinLen || depth >= radixSortMaxDepth {
+			// The common prefix doesn't change the order of the strings.
+			sort.Strings(values)
+			return
+		}
+		// Strings that end at the depth are in bucket 0, and the other strings are in the bucket of
+		// their byte at the depth plus one.
+		var start [258]int
+		for _, v := range values {
+			start[radixByte(v, depth)+1]++
+		}
+		if b := radixByte(values[0], depth); start[b+1] == len(values) {
+			// All the strings are in the same bucket.
+			if b == 0 {
+				// All the strings ended, they are equal.
+				return
+			}
+			// Continue to the next byte without recursion.
+			depth++
+			continue
+		}
+		for b := 1; b < len(start); b++ {
+			start[b] += start[b-1]
+		}
+		pos := start
+		for _, v := range values {
+			b := radixByte(v, depth)
+			tmp[pos[b]] = v
+			pos[b]++
+		}
+		copy(values, tmp)
+
+		// Strings in bucket 0 are equal, sort the strings in each of the other buckets by the next
+		// byte.
+		for b := 1; b < len(start)-1; b++ {
+			lo, hi := start[b], start[b+1]
+			radixSortStrings(values[lo:hi], tmp[lo:hi], depth+1)
+		}
+		return
+	}
+}
+
+// radixByte returns the bucket of a string according to its byte at the given depth.
+func radixByte(v string, depth int) int {
+	if depth >= len(v) {
+		return 0
+	}
+	return int(v[depth]) + 1
+}
@@ -0,0 +1,77 @@
+package order
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortRadix(t *testing.T) {
+	t.Parallel()
+
+	type myInt8 int8
+	type myString string
+
+	rnd := rand.New(rand.NewSource(1))
+	const n = 4 * radixSortMinLen
+
+	ints := make([]int, n)
+	int8s := make([]myInt8, n)
+	uint16s := make([]uint16, n)
+	uint64s := make([]uint64, n)
+	strs := make([]string, n)
+	myStrs := make([]myString, n)
+	for i := 0; i < n; i++ {
+		ints[i] = rnd.Int() - math.MaxInt64/2
+		int8s[i] = myInt8(rnd.Intn(256) - 128)
+		uint16s[i] = uint16(rnd.Intn(1 << 16))
+		uint64s[i] = rnd.Uint64()
+		// Strings with common prefixes and of different lengths.
+		strs[i] = strings.Repeat("a", rnd.Intn(3)) + string(rune('a'+rnd.Intn(3))) + strings.Repeat("b", rnd.Intn(300))
+		myStrs[i] = myString(strs[i])
+	}
+	ints[0], ints[1] = math.MinInt64, math.MaxInt64
+
+	// The expected results are sorted by comparisons.
+	tests := []struct {
+		name  string
+		slice interface{}
+		fns   Fns
+	}{
+		{name: "ints", slice: ints, fns: By(func(a, b int) bool { return a < b })},
+		{name: "named int8s", slice: int8s, fns: By(func(a, b myInt8) bool { return a < b })},
+		{name: "uint16s", slice: uint16s, fns: By(func(a, b uint16) bool { return a < b })},
+		{name: "uint64s", slice: uint64s, fns: By(func(a, b uint64) bool { return a < b })},
+		{name: "strings", slice: strs, fns: By(func(a, b string) bool { return a < b })},
+		{name: "named strings", slice: myStrs, fns: By(func(a, b myString) bool { return a < b })},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := tt.fns.Sorted(tt.slice)
+			assert.Equal(t, want, Sorted(tt.slice))
+			assert.Equal(t, want, SortedStable(tt.slice))
+		})
+	}
+}
+
+func TestSortRadix_longCommonPrefix(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	prefix := strings.Repeat("a", 200000)
+	values := make([]string, 300)
+	for i := range values {
+		values[i] = prefix + strings.Repeat("b", rnd.Intn(3)) + string(rune('a'+rnd.Intn(26)))
+	}
+
+	want := By(func(a, b string) bool { return a < b }).Sorted(values)
+	Sort(values)
+	assert.Equal(t, want, values)
+}
@@ -0,0 +1,314 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Range is a range of values of type T between Lo and Hi, according to the comparison functions. A
+// nil Lo or Hi means that the range is unbounded from that side. A range is empty if there is no
+// value that it contains. For example, the range [1, 3) could be defined by:
+//
+//	order.Range{Fns: fns, Lo: 1, Hi: 3, LoInclusive: true}
+//
+// A range without comparison functions compares its values as the package level functions, by the
+// functions of the type of its bounds.
+type Range struct {
+	Fns
+	Lo, Hi                   interface{}
+	LoInclusive, HiInclusive bool
+}
+
+// Interval is a range of values of type T between lo and hi. It is the same as Range, and is
+// created by Fns.Interval.
+type Interval = Range
+
+// Interval returns the range of values between lo and hi, where bounds defines whether lo and hi
+// are contained in the range. A nil lo or hi means that the range is unbounded from that side. It
+// panics if lo or hi are not of type T.
+func (fns Fns) Interval(lo, hi interface{}, bounds Bounds) Interval {
+	for _, v := range []interface{}{lo, hi} {
+		if v != nil {
+			fns.value(v)
+		}
+	}
+	loInclusive, hiInclusive := bounds.inclusive()
+	return Interval{Fns: fns, Lo: lo, Hi: hi, LoInclusive: loInclusive, HiInclusive: hiInclusive}
+}
+
+// Contains returns whether the value is in the range.
+func (r Range) Contains(value interface{}) bool {
+	r = r.withFns(value)
+	v := r.value(value)
+	if r.Lo != nil {
+		cmp := r.compare(v, r.value(r.Lo))
+		if cmp < 0 || (cmp == 0 && !r.LoInclusive) {
+			return false
+		}
+	}
+	if r.Hi != nil {
+		cmp := r.compare(v, r.value(r.Hi))
+		if cmp > 0 || (cmp == 0 && !r.HiInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty returns whether the range contains no values.
+func (r Range) IsEmpty() bool {
+	if r.Lo == nil || r.Hi == nil {
+		return false
+	}
+	r = r.withFns()
+	cmp := r.compare(r.value(r.Lo), r.value(r.Hi))
+	return cmp > 0 || (cmp == 0 && !(r.LoInclusive && r.HiInclusive))
+}
+
+// Overlaps returns whether there is a value that is contained in both ranges.
+func (r Range) Overlaps(other Range) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// Intersect returns the range of values that are contained in both ranges. The result might be an
+// empty range.
+func (r Range) Intersect(other Range) Range {
+	r = r.withFns(other.Lo, other.Hi)
+	result := Range{Fns: r.Fns}
+	if r.compareLo(r, other) >= 0 {
+		result.Lo, result.LoInclusive = r.Lo, r.LoInclusive
+	} else {
+		result.Lo, result.LoInclusive = other.Lo, other.LoInclusive
+	}
+	if r.compareHi(r, other) <= 0 {
+		result.Hi, result.HiInclusive = r.Hi, r.HiInclusive
+	} else {
+		result.Hi, result.HiInclusive = other.Hi, other.HiInclusive
+	}
+	return result
+}
+
+// Union returns the range of values that are contained in any of the ranges, if the ranges overlap
+// or are adjacent. Otherwise, the union is not a single range and ok is false. An empty range is
+// ignored.
+func (r Range) Union(other Range) (union Range, ok bool) {
+	r = r.withFns(other.Lo, other.Hi)
+	switch {
+	case other.IsEmpty():
+		return r, true
+	case r.IsEmpty():
+		other.Fns = r.Fns
+		return other, true
+	}
+	a, b := r, other
+	if r.compareLo(a, b) > 0 {
+		a, b = b, a
+	}
+	if !r.connected(a, b) {
+		return Range{}, false
+	}
+	union = Range{Fns: r.Fns, Lo: a.Lo, LoInclusive: a.LoInclusive}
+	if r.compareHi(a, b) >= 0 {
+		union.Hi, union.HiInclusive = a.Hi, a.HiInclusive
+	} else {
+		union.Hi, union.HiInclusive = b.Hi, b.HiInclusive
+	}
+	return union, true
+}
+
+// Compare is a three-way comparison of two ranges, which orders ranges by their lower bound, and
+// then by their upper bound. An unbounded side is less than any lower bound, or greater than any
+// upper bound. For bounds of equal values, an inclusive lower bound is less than an exclusive one,
+// and an exclusive upper bound is less than an inclusive one.
+func (r Range) Compare(other Range) int {
+	r = r.withFns(other.Lo, other.Hi)
+	if cmp := r.compareLo(r, other); cmp != 0 {
+		return cmp
+	}
+	return r.compareHi(r, other)
+}
+
+func (r Range) String() string {
+	lo, hi := "(-inf", "+inf)"
+	if r.Lo != nil {
+		lo = fmt.Sprintf("(%v", r.Lo)
+		if r.LoInclusive {
+			lo = fmt.Sprintf("[%v", r.Lo)
+		}
+	}
+	if r.Hi != nil {
+		hi = fmt.Sprintf("%v)", r.Hi)
+		if r.HiInclusive {
+			hi = fmt.Sprintf("%v]", r.Hi)
+		}
+	}
+	return lo + ", " + hi
+}
+
+// NormalizeRanges returns the minimal list of disjoint, non-adjacent and non-empty ranges that
+// contain the same values as the given ranges, sorted in increasing order. The comparison functions
+// of the first range are used, or if it has none, the functions of the type of the first bound.
+func NormalizeRanges(ranges []Range) []Range {
+	var sorted []Range
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			sorted = append(sorted, r)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	fns := ranges[0].withFns(rangesBounds(ranges)...).Fns
+	sort.SliceStable(sorted, func(i, j int) bool { return fns.compareLo(sorted[i], sorted[j]) < 0 })
+
+	result := []Range{sorted[0]}
+	result[0].Fns = fns
+	for _, r := range sorted[1:] {
+		last := &result[len(result)-1]
+		if union, ok := last.Union(r); ok {
+			*last = union
+		} else {
+			r.Fns = fns
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// MergeIntervals sorts the intervals and coalesces intervals that overlap or are adjacent, such as
+// [1, 2) and [2, 3), into the minimal set of disjoint intervals that contain the same values. Empty
+// intervals are dropped. It is the same as NormalizeRanges.
+func MergeIntervals(intervals []Interval) []Interval {
+	return NormalizeRanges(intervals)
+}
+
+// compareLo compares the lower bounds of two ranges. An unbounded lower bound is the smallest, and
+// for equal values, an inclusive bound is smaller than an exclusive bound.
+func (fns Fns) compareLo(a, b Range) int {
+	switch {
+	case a.Lo == nil && b.Lo == nil:
+		return 0
+	case a.Lo == nil:
+		return -1
+	case b.Lo == nil:
+		return 1
+	}
+	if cmp := fns.compare(fns.value(a.Lo), fns.value(b.Lo)); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case a.LoInclusive == b.LoInclusive:
+		return 0
+	case a.LoInclusive:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareHi compares the upper bounds of two ranges. An unbounded upper bound is the greatest, and
+// for equal values, an exclusive bound is smaller than an inclusive bound.
+func (fns Fns) compareHi(a, b Range) int {
+	switch {
+	case a.Hi == nil && b.Hi == nil:
+		return 0
+	case a.Hi == nil:
+		return 1
+	case b.Hi == nil:
+		return -1
+	}
+	if cmp := fns.compare(fns.value(a.Hi), fns.value(b.Hi)); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case a.HiInclusive == b.HiInclusive:
+		return 0
+	case a.HiInclusive:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// connected returns whether two non-empty ranges, where a's lower bound is not greater than b's
+// lower bound, overlap or are adjacent.
+func (fns Fns) connected(a, b Range) bool {
+	if a.Hi == nil || b.Lo == nil {
+		return true
+	}
+	cmp := fns.compare(fns.value(a.Hi), fns.value(b.Lo))
+	return cmp > 0 || (cmp == 0 && (a.HiInclusive || b.LoInclusive))
+}
+
+// withFns returns the range with comparison functions. If the range has no comparison functions,
+// the functions of the type of its first bound, or of the first of the given values, are used, as
+// in the package level functions. The range is returned as is if all of them are nil.
+func (r Range) withFns(values ...interface{}) Range {
+	if len(r.Fns) > 0 {
+		return r
+	}
+	for _, v := range append([]interface{}{r.Lo, r.Hi}, values...) {
+		if v != nil {
+			r.Fns = compareableFn(reflect.TypeOf(v))
+			return r
+		}
+	}
+	return r
+}
+
+// rangesBounds returns the bounds of the given ranges.
+func rangesBounds(ranges []Range) []interface{} {
+	bounds := make([]interface{}, 0, 2*len(ranges))
+	for _, r := range ranges {
+		bounds = append(bounds, r.Lo, r.Hi)
+	}
+	return bounds
+}
+
+// value returns the reflect value of a range bound. It panics if the bound is not of type T.
+func (fns Fns) value(v interface{}) reflect.Value {
+	return fns.mustValue(reflect.ValueOf(v))
+}
+
+// Gaps returns the ranges of values that are contained in `within`, but are not contained in any
+// of the given ranges. The gaps are disjoint and sorted in increasing order. The comparison
+// functions of `within` are used, or if it has none, the functions of the type of the first bound.
+func Gaps(ranges []Range, within Range) []Range {
+	within = within.withFns(rangesBounds(ranges)...)
+	if within.IsEmpty() {
+		return nil
+	}
+	clipped := make([]Range, 0, len(ranges))
+	for _, r := range ranges {
+		clipped = append(clipped, within.Intersect(r))
+	}
+
+	var gaps []Range
+	// The next gap starts at the lower bound of cur.
+	cur := Range{Fns: within.Fns, Lo: within.Lo, LoInclusive: within.LoInclusive}
+	for _, r := range NormalizeRanges(clipped) {
+		if r.Lo != nil {
+			gap := cur
+			gap.Hi, gap.HiInclusive = r.Lo, !r.LoInclusive
+			if !gap.IsEmpty() {
+				gaps = append(gaps, gap)
+			}
+		}
+		if r.Hi == nil {
+			return gaps
+		}
+		cur.Lo, cur.LoInclusive = r.Hi, !r.HiInclusive
+	}
+	cur.Hi, cur.HiInclusive = within.Hi, within.HiInclusive
+	if !cur.IsEmpty() {
+		gaps = append(gaps, cur)
+	}
+	return gaps
+}
+
+// Covers returns whether every value that is contained in `within` is contained in one of the given
+// ranges. The comparison functions of `within` are used.
+func Covers(ranges []Range, within Range) bool {
+	return len(Gaps(ranges, within)) == 0
+}
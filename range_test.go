@@ -0,0 +1,293 @@
+package order
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rng returns a range of ints, where nil bounds are unbounded.
+func rng(lo interface{}, loInclusive bool, hi interface{}, hiInclusive bool) Range {
+	return Range{Fns: intFn, Lo: lo, Hi: hi, LoInclusive: loInclusive, HiInclusive: hiInclusive}
+}
+
+func TestRange_Contains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		r     Range
+		in    []int
+		notIn []int
+	}{
+		{r: rng(1, true, 3, false), in: []int{1, 2}, notIn: []int{0, 3, 4}},
+		{r: rng(1, false, 3, true), in: []int{2, 3}, notIn: []int{0, 1, 4}},
+		{r: rng(nil, false, 3, true), in: []int{-100, 3}, notIn: []int{4}},
+		{r: rng(1, true, nil, false), in: []int{1, 100}, notIn: []int{0}},
+		{r: rng(nil, false, nil, false), in: []int{-100, 0, 100}},
+		{r: rng(2, true, 2, false), notIn: []int{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.r.String(), func(t *testing.T) {
+			for _, v := range tt.in {
+				assert.True(t, tt.r.Contains(v), v)
+			}
+			for _, v := range tt.notIn {
+				assert.False(t, tt.r.Contains(v), v)
+			}
+		})
+	}
+}
+
+func TestRange_IsEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, rng(1, true, 1, true).IsEmpty())
+	assert.True(t, rng(1, true, 1, false).IsEmpty())
+	assert.True(t, rng(1, false, 1, true).IsEmpty())
+	assert.True(t, rng(2, true, 1, true).IsEmpty())
+	assert.False(t, rng(nil, false, 1, false).IsEmpty())
+	assert.False(t, rng(1, false, nil, false).IsEmpty())
+}
+
+func TestRange_Intersect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b        Range
+		want        Range
+		wantOverlap bool
+	}{
+		{a: rng(1, true, 5, false), b: rng(3, true, 7, true), want: rng(3, true, 5, false), wantOverlap: true},
+		{a: rng(1, true, 5, true), b: rng(5, true, 7, true), want: rng(5, true, 5, true), wantOverlap: true},
+		{a: rng(1, true, 5, false), b: rng(5, true, 7, true), want: rng(5, true, 5, false)},
+		{a: rng(1, true, 3, true), b: rng(4, true, 7, true), want: rng(4, true, 3, true)},
+		{a: rng(1, true, 5, true), b: rng(1, false, 5, false), want: rng(1, false, 5, false), wantOverlap: true},
+		{a: rng(nil, false, 5, true), b: rng(3, true, nil, false), want: rng(3, true, 5, true), wantOverlap: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a.String()+"/"+tt.b.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want.String(), tt.a.Intersect(tt.b).String())
+			assert.Equal(t, tt.want.String(), tt.b.Intersect(tt.a).String())
+			assert.Equal(t, tt.wantOverlap, tt.a.Overlaps(tt.b))
+			assert.Equal(t, tt.wantOverlap, tt.b.Overlaps(tt.a))
+		})
+	}
+}
+
+func TestRange_Union(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b   Range
+		want   Range
+		wantOK bool
+	}{
+		{a: rng(1, true, 5, false), b: rng(3, true, 7, true), want: rng(1, true, 7, true), wantOK: true},
+		{a: rng(1, true, 5, false), b: rng(5, true, 7, true), want: rng(1, true, 7, true), wantOK: true},
+		{a: rng(1, true, 5, true), b: rng(5, false, 7, true), want: rng(1, true, 7, true), wantOK: true},
+		{a: rng(1, true, 5, false), b: rng(5, false, 7, true)},
+		{a: rng(1, true, 3, true), b: rng(4, true, 7, true)},
+		{a: rng(1, true, 7, true), b: rng(3, true, 5, false), want: rng(1, true, 7, true), wantOK: true},
+		{a: rng(nil, false, 5, true), b: rng(3, true, nil, false), want: rng(nil, false, nil, false), wantOK: true},
+		{a: rng(1, true, 3, true), b: rng(8, true, 7, true), want: rng(1, true, 3, true), wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a.String()+"/"+tt.b.String(), func(t *testing.T) {
+			for _, got := range []func() (Range, bool){
+				func() (Range, bool) { return tt.a.Union(tt.b) },
+				func() (Range, bool) { return tt.b.Union(tt.a) },
+			} {
+				union, ok := got()
+				assert.Equal(t, tt.wantOK, ok)
+				if tt.wantOK {
+					assert.Equal(t, tt.want.String(), union.String())
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeRanges(t *testing.T) {
+	t.Parallel()
+
+	got := NormalizeRanges([]Range{
+		rng(10, true, 12, false),
+		rng(1, true, 3, false),
+		rng(5, false, 6, true),
+		rng(2, true, 4, false),
+		rng(7, true, 7, false),
+		rng(4, true, 5, false),
+		rng(12, false, nil, false),
+	})
+	want := []string{"[1, 5)", "(5, 6]", "[10, 12)", "(12, +inf)"}
+	var gotStr []string
+	for _, r := range got {
+		gotStr = append(gotStr, r.String())
+	}
+	assert.Equal(t, want, gotStr)
+
+	assert.Nil(t, NormalizeRanges(nil))
+	assert.Nil(t, NormalizeRanges([]Range{rng(2, true, 1, true)}))
+}
+
+func TestMergeIntervals(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return t0.Add(time.Duration(h) * time.Hour) }
+	times := By(compareTime)
+
+	got := MergeIntervals([]Interval{
+		times.Interval(at(13), at(14), ClosedOpen),
+		times.Interval(at(9), at(11), ClosedOpen),
+		times.Interval(at(10), at(12), ClosedOpen),
+		times.Interval(at(12), at(13), ClosedOpen),
+		times.Interval(at(16), at(17), Open),
+		times.Interval(at(18), at(18), ClosedOpen),
+	})
+	want := []Interval{
+		times.Interval(at(9), at(14), ClosedOpen),
+		times.Interval(at(16), at(17), Open),
+	}
+	assert.Equal(t, len(want), len(got))
+	for i := range want {
+		assert.Equal(t, 0, want[i].Compare(got[i]), "%v != %v", want[i], got[i])
+	}
+
+	assert.Nil(t, MergeIntervals(nil))
+}
+
+func TestRange_invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { rng(1, true, 3, true).Contains("a") })
+	assert.Panics(t, func() { rng("a", true, 3, true).Contains(2) })
+	assert.Panics(t, func() { rng(1, true, 3, true).Intersect(rng(1, true, "b", true)) })
+}
+
+func TestRange_noFns(t *testing.T) {
+	t.Parallel()
+
+	// Ranges without comparison functions use the functions of the type of their bounds.
+	normalized := NormalizeRanges([]Range{{Lo: 1, Hi: 2}, {Lo: 2, Hi: 3, LoInclusive: true}})
+	assert.Equal(t, "[(1, 3)]", fmt.Sprint(normalized))
+	assert.True(t, normalized[0].Contains(2))
+	assert.True(t, Range{Lo: 1, Hi: 3}.Contains(2))
+	assert.True(t, Range{}.Contains("a"))
+	assert.True(t, Range{Lo: 2, Hi: 1}.IsEmpty())
+	assert.True(t, Range{Lo: 1}.Overlaps(Range{Hi: 2}))
+	assert.Equal(t, -1, Range{Lo: 1}.Compare(Range{Lo: 2}))
+	assert.Equal(t, "[[1, 1] [2, 3)]",
+		fmt.Sprint(Gaps([]Range{{Lo: 1, Hi: 2}}, Range{Lo: 1, Hi: 3, LoInclusive: true})))
+	assert.Nil(t, NormalizeRanges([]Range{{Lo: 1, Hi: 1}}))
+}
+
+func TestGaps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		ranges []Range
+		within Range
+		want   []string
+	}{
+		{name: "no ranges", within: rng(1, true, 5, false), want: []string{"[1, 5)"}},
+		{name: "empty within", ranges: []Range{rng(1, true, 2, true)}, within: rng(5, true, 1, true)},
+		{
+			name:   "covered",
+			ranges: []Range{rng(0, true, 3, false), rng(3, true, 10, true)},
+			within: rng(1, true, 5, false),
+		},
+		{
+			name:   "gaps",
+			ranges: []Range{rng(2, true, 3, false), rng(6, false, 7, true), rng(20, true, 30, true)},
+			within: rng(1, true, 10, false),
+			want:   []string{"[1, 2)", "[3, 6]", "(7, 10)"},
+		},
+		{
+			name:   "unbounded",
+			ranges: []Range{rng(2, false, 3, true)},
+			within: rng(nil, false, nil, false),
+			want:   []string{"(-inf, 2]", "(3, +inf)"},
+		},
+		{
+			name:   "unbounded ranges",
+			ranges: []Range{rng(nil, false, 3, true), rng(5, true, nil, false)},
+			within: rng(1, true, 10, false),
+			want:   []string{"(3, 5)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range Gaps(tt.ranges, tt.within) {
+				got = append(got, r.String())
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, len(tt.want) == 0, Covers(tt.ranges, tt.within))
+		})
+	}
+}
+
+func TestFns_Interval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		bounds Bounds
+		want   Interval
+	}{
+		{bounds: Closed, want: rng(1, true, 3, true)},
+		{bounds: Open, want: rng(1, false, 3, false)},
+		{bounds: ClosedOpen, want: rng(1, true, 3, false)},
+		{bounds: OpenClosed, want: rng(1, false, 3, true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			got := intFn.Interval(1, 3, tt.bounds)
+			assert.Equal(t, tt.want.String(), got.String())
+			assert.Equal(t, 0, got.Compare(tt.want))
+		})
+	}
+
+	// Intervals of times.
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := By(compareTime)
+	morning := times.Interval(t0.Add(8*time.Hour), t0.Add(12*time.Hour), ClosedOpen)
+	assert.True(t, morning.Contains(t0.Add(8*time.Hour)))
+	assert.False(t, morning.Contains(t0.Add(12*time.Hour)))
+	assert.True(t, morning.Overlaps(times.Interval(t0.Add(11*time.Hour), nil, Closed)))
+	assert.False(t, morning.Overlaps(times.Interval(t0.Add(12*time.Hour), nil, Closed)))
+
+	assert.Panics(t, func() { intFn.Interval(1, "3", Closed) })
+	assert.Panics(t, func() { intFn.Interval(1, 3, Bounds(-1)) })
+}
+
+func TestRange_Compare(t *testing.T) {
+	t.Parallel()
+
+	// Ranges in increasing order.
+	ranges := []Range{
+		rng(nil, false, 1, false),
+		rng(nil, false, nil, false),
+		rng(1, true, 2, false),
+		rng(1, true, 2, true),
+		rng(1, true, nil, false),
+		rng(1, false, 2, false),
+		rng(2, true, 3, true),
+	}
+
+	for i := range ranges {
+		for j := range ranges {
+			t.Run(ranges[i].String()+"/"+ranges[j].String(), func(t *testing.T) {
+				assert.Equal(t, sign(i-j), sign(ranges[i].Compare(ranges[j])))
+			})
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package order
+
+import (
+	"sort"
+)
+
+// RangeSet is a collection of ranges of values, ordered by comparison functions. The set is kept
+// as the minimal sorted list of disjoint ranges that contain the values of all the added ranges,
+// such that overlapping or adjacent ranges are merged. A RangeSet is not safe for concurrent use.
+type RangeSet struct {
+	fns Fns
+	// ranges are disjoint, non-adjacent and non-empty, in increasing order.
+	ranges []Range
+}
+
+// NewRangeSet returns an empty RangeSet of ranges of values of type T, ordered by the given
+// comparison functions.
+func NewRangeSet(fns Fns) *RangeSet {
+	return &RangeSet{fns: fns}
+}
+
+// Add adds the values of a range to the set. The comparison functions of the set are used, and not
+// the ones of the given range. An empty range is ignored. It panics if the bounds of the range are
+// not of type T.
+func (s *RangeSet) Add(r Range) {
+	r.Fns = s.fns
+	if r.IsEmpty() {
+		return
+	}
+	// The ranges before i end before r starts, and are not adjacent to it.
+	i := sort.Search(len(s.ranges), func(i int) bool { return !s.endsBefore(s.ranges[i], r) })
+	j := i
+	for ; j < len(s.ranges); j++ {
+		union, ok := r.Union(s.ranges[j])
+		if !ok {
+			break
+		}
+		r = union
+	}
+	s.ranges = append(s.ranges[:i], append([]Range{r}, s.ranges[j:]...)...)
+}
+
+// Contains returns whether the value is contained in one of the ranges of the set. It panics if the
+// value is not of type T.
+func (s *RangeSet) Contains(value interface{}) bool {
+	v := s.fns.value(value)
+	// The first range which does not end before the value.
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		r := s.ranges[i]
+		if r.Hi == nil {
+			return true
+		}
+		cmp := s.fns.compare(v, s.fns.value(r.Hi))
+		return cmp < 0 || (cmp == 0 && r.HiInclusive)
+	})
+	return i < len(s.ranges) && s.ranges[i].Contains(value)
+}
+
+// Gaps returns the ranges of values between the ranges of the set, in increasing order. The values
+// before the first range and after the last range are not included. To get the gaps within given
+// bounds, use `order.Gaps`, with the ranges of the set.
+func (s *RangeSet) Gaps() []Range {
+	var gaps []Range
+	for i := 1; i < len(s.ranges); i++ {
+		prev, cur := s.ranges[i-1], s.ranges[i]
+		gaps = append(gaps, Range{
+			Fns:         s.fns,
+			Lo:          prev.Hi,
+			LoInclusive: !prev.HiInclusive,
+			Hi:          cur.Lo,
+			HiInclusive: !cur.LoInclusive,
+		})
+	}
+	return gaps
+}
+
+// Len returns the number of disjoint ranges in the set.
+func (s *RangeSet) Len() int {
+	return len(s.ranges)
+}
+
+// Range calls f sequentially for each of the disjoint ranges of the set, in order. If f returns
+// false, range stops the iteration. The set should not be modified during the iteration.
+func (s *RangeSet) Range(f func(r Range) bool) {
+	for _, r := range s.ranges {
+		if !f(r) {
+			return
+		}
+	}
+}
+
+// endsBefore returns whether the range a ends before the range b starts, and they are not adjacent.
+func (s *RangeSet) endsBefore(a, b Range) bool {
+	if a.Hi == nil || b.Lo == nil {
+		return false
+	}
+	cmp := s.fns.compare(s.fns.value(a.Hi), s.fns.value(b.Lo))
+	return cmp < 0 || (cmp == 0 && !a.HiInclusive && !b.LoInclusive)
+}
@@ -0,0 +1,90 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rangeSetStrings returns the string representations of the ranges of a set.
+func rangeSetStrings(s *RangeSet) []string {
+	var got []string
+	s.Range(func(r Range) bool {
+		got = append(got, r.String())
+		return true
+	})
+	return got
+}
+
+func TestRangeSet(t *testing.T) {
+	t.Parallel()
+
+	s := NewRangeSet(intFn)
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Contains(1))
+	assert.Nil(t, s.Gaps())
+
+	s.Add(rng(10, true, 20, false))
+	s.Add(rng(1, true, 3, false))
+	s.Add(rng(30, false, 40, true))
+	s.Add(rng(5, true, 5, false)) // Empty.
+	assert.Equal(t, []string{"[1, 3)", "[10, 20)", "(30, 40]"}, rangeSetStrings(s))
+
+	// Adjacent ranges are merged.
+	s.Add(rng(3, true, 4, false))
+	assert.Equal(t, []string{"[1, 4)", "[10, 20)", "(30, 40]"}, rangeSetStrings(s))
+
+	// Ranges which end exclusively at the same value are not adjacent.
+	s.Add(rng(4, false, 5, true))
+	assert.Equal(t, []string{"[1, 4)", "(4, 5]", "[10, 20)", "(30, 40]"}, rangeSetStrings(s))
+
+	// A range that overlaps multiple ranges.
+	s.Add(rng(15, true, 30, true))
+	assert.Equal(t, []string{"[1, 4)", "(4, 5]", "[10, 40]"}, rangeSetStrings(s))
+	assert.Equal(t, 3, s.Len())
+
+	for _, v := range []int{1, 3, 5, 10, 25, 40} {
+		assert.True(t, s.Contains(v), v)
+	}
+	for _, v := range []int{0, 4, 6, 9, 41} {
+		assert.False(t, s.Contains(v), v)
+	}
+
+	var gaps []string
+	for _, r := range s.Gaps() {
+		gaps = append(gaps, r.String())
+	}
+	assert.Equal(t, []string{"[4, 4]", "(5, 10)"}, gaps)
+
+	// An unbounded range.
+	s.Add(rng(nil, false, 2, true))
+	assert.Equal(t, []string{"(-inf, 4)", "(4, 5]", "[10, 40]"}, rangeSetStrings(s))
+	assert.True(t, s.Contains(-100))
+	s.Add(rng(0, true, nil, false))
+	assert.Equal(t, []string{"(-inf, +inf)"}, rangeSetStrings(s))
+	assert.True(t, s.Contains(100))
+}
+
+func TestRangeSet_rangeStop(t *testing.T) {
+	t.Parallel()
+
+	s := NewRangeSet(intFn)
+	s.Add(rng(1, true, 2, true))
+	s.Add(rng(3, true, 4, true))
+
+	var got []Range
+	s.Range(func(r Range) bool {
+		got = append(got, r)
+		return false
+	})
+	assert.Len(t, got, 1)
+}
+
+func TestRangeSet_invalid(t *testing.T) {
+	t.Parallel()
+
+	s := NewRangeSet(intFn)
+	assert.Panics(t, func() { s.Add(rng("a", true, "b", true)) })
+	s.Add(rng(1, true, 2, true))
+	assert.Panics(t, func() { s.Contains("a") })
+}
@@ -0,0 +1,163 @@
+package order
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// Ties is a policy for ranking equal elements with Fns.Ranks.
+type Ties int
+
+const (
+	// TiesAverage gives equal elements the average of the ranks that they span.
+	TiesAverage Ties = iota
+	// TiesMin gives equal elements the minimal rank that they span.
+	TiesMin
+	// TiesMax gives equal elements the maximal rank that they span.
+	TiesMax
+	// TiesDense gives equal elements the same rank, and the following greater elements the next
+	// rank, such that ranks are consecutive.
+	TiesDense
+)
+
+// Ranks returns the rank of each element of the given slice according to the comparison function,
+// without modifying the slice. The i'th returned value is the rank of slice[i], where the smallest
+// element has rank 1. Equal elements are ranked according to the ties policy. For example, the
+// ranks of [10, 20, 20, 30] are [1, 2.5, 2.5, 4] with TiesAverage, [1, 2, 2, 4] with TiesMin,
+// [1, 3, 3, 4] with TiesMax and [1, 2, 2, 3] with TiesDense.
+func (fns Fns) Ranks(slice interface{}, ties Ties) []float64 {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	perm := fns.argsort(s)
+	ranks := make([]float64, len(perm))
+	dense := 0
+	for start := 0; start < len(perm); {
+		// Find the end of the run of equal elements in the sorted order.
+		end := start + 1
+		for end < len(perm) && fns.compare(s.Index(perm[start]), s.Index(perm[end])) == 0 {
+			end++
+		}
+		dense++
+
+		var rank float64
+		switch ties {
+		case TiesAverage:
+			rank = float64(start+1+end) / 2
+		case TiesMin:
+			rank = float64(start + 1)
+		case TiesMax:
+			rank = float64(end)
+		case TiesDense:
+			rank = float64(dense)
+		default:
+			panic(fmt.Sprintf("unknown ties policy: %d", ties))
+		}
+		for _, i := range perm[start:end] {
+			ranks[i] = rank
+		}
+		start = end
+	}
+	return ranks
+}
+
+// Rank returns the number of elements in the given slice that are less than the given value,
+// according to the comparison function. The given slice should be sorted relative to the comparison
+// function. Dividing the rank by the slice length gives the percentile of the value in the slice.
+func (fns Fns) Rank(slice, value interface{}) int {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	return fns.lowerBound(s, fns.mustValue(reflect.ValueOf(value)))
+}
+
+// Inversions returns the number of pairs of elements in the given slice that are out of order
+// according to the comparison function: pairs i < j such that slice[i] > slice[j]. It is 0 for a
+// sorted slice, and n*(n-1)/2 for a strictly decreasing slice of n elements. The slice is not
+// modified, and the computation takes O(n*log(n)) time.
+func (fns Fns) Inversions(slice interface{}) int {
+	return fns.inversions(fns.values(fns.mustSlice(reflect.ValueOf(slice))))
+}
+
+// values returns the elements of a slice.
+func (fns Fns) values(s reflectutil.Slice) []reflect.Value {
+	values := make([]reflect.Value, s.Len())
+	for i := range values {
+		values[i] = s.Index(i)
+	}
+	return values
+}
+
+// inversions sorts the given values with a stable merge sort, and returns the number of inversions
+// that were fixed.
+func (fns Fns) inversions(values []reflect.Value) int {
+	buf := make([]reflect.Value, len(values))
+	var mergeSort func(a []reflect.Value) int
+	mergeSort = func(a []reflect.Value) int {
+		if len(a) < 2 {
+			return 0
+		}
+		mid := len(a) / 2
+		n := mergeSort(a[:mid]) + mergeSort(a[mid:])
+
+		// Merge the sorted halves. When an element of the right half is taken, it is inverted with
+		// all the remaining elements of the left half.
+		b := buf[:len(a)]
+		i, j := 0, mid
+		for k := range b {
+			if j == len(a) || (i < mid && fns.compare(a[i], a[j]) <= 0) {
+				b[k] = a[i]
+				i++
+			} else {
+				b[k] = a[j]
+				j++
+				n += mid - i
+			}
+		}
+		copy(a, b)
+		return n
+	}
+	return mergeSort(values)
+}
+
+// Correlation returns the Kendall rank correlation coefficient (tau-b) between the orders that two
+// comparison functions induce over the elements of the given slice. It is 1 if the orders are the
+// same, -1 if one order is the reverse of the other, and about 0 if they are independent. Ties are
+// accounted for, and if all the elements are equal according to one of the comparison functions, the
+// correlation is not defined and NaN is returned. The slice is not modified, and the computation
+// takes O(n*log(n)) time.
+func Correlation(fnsA, fnsB Fns, slice interface{}) float64 {
+	v := reflect.ValueOf(slice)
+	fnsB.mustSlice(v)
+	s := fnsA.mustSlice(v)
+
+	// Sort the values by A, and then by B, and count the pairs that are tied in A, and in both.
+	values := fnsB.values(s)
+	both := append(append(Fns{}, fnsA...), fnsB...)
+	sort.SliceStable(values, func(i, j int) bool { return both.compare(values[i], values[j]) < 0 })
+	tiesA := fnsA.tiedPairs(values)
+	tiesBoth := both.tiedPairs(values)
+
+	// The discordant pairs are the pairs that are out of order according to B.
+	discordant := fnsB.inversions(values)
+	tiesB := fnsB.tiedPairs(values)
+
+	n := len(values) * (len(values) - 1) / 2
+	concordantMinusDiscordant := n - tiesA - tiesB + tiesBoth - 2*discordant
+	return float64(concordantMinusDiscordant) / math.Sqrt(float64(n-tiesA)*float64(n-tiesB))
+}
+
+// tiedPairs returns the number of pairs of equal values in a sorted list of values.
+func (fns Fns) tiedPairs(values []reflect.Value) int {
+	pairs := 0
+	for start := 0; start < len(values); {
+		end := start + 1
+		for end < len(values) && fns.compare(values[start], values[end]) == 0 {
+			end++
+		}
+		t := end - start
+		pairs += t * (t - 1) / 2
+		start = end
+	}
+	return pairs
+}
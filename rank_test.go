@@ -0,0 +1,140 @@
+package order
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRanks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		ties  Ties
+		want  []float64
+	}{
+		{slice: nil, ties: TiesAverage, want: []float64{}},
+		{slice: []int{30, 10, 20}, ties: TiesAverage, want: []float64{3, 1, 2}},
+		{slice: []int{10, 20, 20, 30}, ties: TiesAverage, want: []float64{1, 2.5, 2.5, 4}},
+		{slice: []int{10, 20, 20, 30}, ties: TiesMin, want: []float64{1, 2, 2, 4}},
+		{slice: []int{10, 20, 20, 30}, ties: TiesMax, want: []float64{1, 3, 3, 4}},
+		{slice: []int{10, 20, 20, 30}, ties: TiesDense, want: []float64{1, 2, 2, 3}},
+		{slice: []int{5, 5, 5, 1}, ties: TiesAverage, want: []float64{3, 3, 3, 1}},
+		{slice: []int{5, 5, 5, 1}, ties: TiesDense, want: []float64{2, 2, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%d", tt.slice, tt.ties), func(t *testing.T) {
+			assert.Equal(t, tt.want, intFn.Ranks(tt.slice, tt.ties))
+		})
+	}
+
+	assert.Panics(t, func() { intFn.Ranks([]int{1}, Ties(10)) })
+}
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{1, 3, 3, 5}
+	tests := []struct {
+		value, want int
+	}{
+		{value: 0, want: 0},
+		{value: 1, want: 0},
+		{value: 2, want: 1},
+		{value: 3, want: 1},
+		{value: 4, want: 3},
+		{value: 5, want: 3},
+		{value: 6, want: 4},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Rank(slice, tt.value), tt.value)
+	}
+	assert.Equal(t, 0, Rank([]int{}, 1))
+	assert.Panics(t, func() { intFn.Rank(slice, "a") })
+}
+
+func TestInversions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  int
+	}{
+		{slice: []int{}, want: 0},
+		{slice: []int{1}, want: 0},
+		{slice: []int{1, 2, 2, 3}, want: 0},
+		{slice: []int{2, 1}, want: 1},
+		{slice: []int{4, 3, 2, 1}, want: 6},
+		{slice: []int{2, 2, 1, 1}, want: 4},
+		{slice: []int{1, 3, 5, 2, 4, 6}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.slice), func(t *testing.T) {
+			original := copySlice(tt.slice)
+			assert.Equal(t, tt.want, Inversions(tt.slice))
+			assert.Equal(t, original, tt.slice)
+		})
+	}
+}
+
+func TestInversions_random(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 50; n++ {
+		slice := rnd.Perm(n)
+		for i := range slice {
+			slice[i] %= 7
+		}
+		want := 0
+		for i := range slice {
+			for j := i + 1; j < len(slice); j++ {
+				if slice[i] > slice[j] {
+					want++
+				}
+			}
+		}
+		assert.Equal(t, want, intFn.Inversions(slice), slice)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	t.Parallel()
+
+	type pair struct{ a, b int }
+	byA := By(func(x, y pair) int { return x.a - y.a })
+	byB := By(func(x, y pair) int { return x.b - y.b })
+
+	tests := []struct {
+		name  string
+		pairs []pair
+		want  float64
+	}{
+		{name: "same", pairs: []pair{{1, 10}, {3, 30}, {2, 20}}, want: 1},
+		{name: "reversed", pairs: []pair{{1, 30}, {3, 10}, {2, 20}}, want: -1},
+		// Of the 6 pairs, 5 are concordant and 1 is discordant.
+		{name: "partial", pairs: []pair{{1, 1}, {2, 3}, {3, 2}, {4, 4}}, want: 4.0 / 6},
+		// Of the 6 pairs, 1 is concordant, 2 are discordant, 1 is tied in a and 2 are tied in b.
+		{name: "ties", pairs: []pair{{1, 1}, {1, 2}, {2, 2}, {3, 1}}, want: -1 / math.Sqrt(5*4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Correlation(byA, byB, tt.pairs), 1e-9)
+			assert.InDelta(t, tt.want, Correlation(byB, byA, tt.pairs), 1e-9)
+		})
+	}
+
+	// All elements are equal according to one of the orders.
+	assert.True(t, math.IsNaN(Correlation(byA, byB, []pair{{1, 1}, {1, 2}})))
+	assert.True(t, math.IsNaN(Correlation(byA, byB, []pair{})))
+
+	assert.Panics(t, func() { Correlation(byA, intFn, []pair{}) })
+}
@@ -0,0 +1,163 @@
+package order
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// Repair is a strategy for making a slice sorted with Fns.MakeSorted.
+type Repair int
+
+const (
+	// RepairIsotonic replaces the values of the slice with the closest (in least squares) sorted
+	// values, using the pool adjacent violators algorithm: runs of elements that are out of order
+	// are replaced with their mean. It is only applicable to slices of numbers. For slices of
+	// integers, the means are rounded.
+	RepairIsotonic Repair = iota
+	// RepairDrop drops the minimal set of elements such that the remaining elements are sorted.
+	RepairDrop
+)
+
+// MakeSorted repairs the given slice such that it will be sorted according to the comparison
+// function, using the given strategy. The slice is modified in place. It returns the repaired slice,
+// of the given slice type, and the indices, in the given slice, of the dropped elements. For
+// RepairDrop, the kept elements are moved to the beginning of the slice in their original order,
+// and the returned slice is truncated to their number. For RepairIsotonic, no element is dropped.
+func (fns Fns) MakeSorted(slice interface{}, strategy Repair) (sorted interface{}, dropped []int) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	switch strategy {
+	case RepairIsotonic:
+		fns.isotonic(s)
+		return s.Interface(), nil
+	case RepairDrop:
+		keep := fns.longestSubsequence(s, false)
+		dropped = complementIndices(keep, s.Len())
+		// Move the kept elements to the beginning of the slice, in order. Since keep is increasing,
+		// keep[i] >= i and the swap does not affect previously moved elements.
+		for i, k := range keep {
+			s.Swap(i, k)
+		}
+		return s.Slice(0, len(keep)).Interface(), dropped
+	default:
+		panic(fmt.Sprintf("unknown repair strategy: %d", strategy))
+	}
+}
+
+// isotonic applies the pool adjacent violators algorithm on the given slice of numbers.
+func (fns Fns) isotonic(s reflectutil.Slice) {
+	tp := s.T()
+	if numValue(reflect.Zero(tp)) == nil {
+		panic(fmt.Sprintf("isotonic repair requires a slice of numbers, got: %v", s.Type()))
+	}
+
+	// block is a pool of adjacent elements that are replaced by their mean.
+	type block struct {
+		sum   float64
+		count int
+		value reflect.Value
+	}
+	mean := func(b block) reflect.Value { return fromFloat(tp, b.sum/float64(b.count)) }
+
+	var blocks []block
+	for i := 0; i < s.Len(); i++ {
+		v := s.Index(i)
+		blocks = append(blocks, block{sum: *numValue(v), count: 1, value: v})
+		// Merge the last block with the previous block as long as they are out of order.
+		for n := len(blocks); n >= 2 && fns.compare(blocks[n-2].value, blocks[n-1].value) > 0; n-- {
+			merged := block{sum: blocks[n-2].sum + blocks[n-1].sum, count: blocks[n-2].count + blocks[n-1].count}
+			merged.value = mean(merged)
+			blocks = append(blocks[:n-2], merged)
+		}
+	}
+
+	// Write the block values back to the slice.
+	i := 0
+	for _, b := range blocks {
+		value := mean(b)
+		for end := i + b.count; i < end; i++ {
+			s.Index(i).Set(value)
+		}
+	}
+}
+
+// longestSubsequence returns the indices of the longest subsequence of the slice that is sorted
+// (strictly sorted if strict is true), using the patience sorting algorithm.
+func (fns Fns) longestSubsequence(s reflectutil.Slice, strict bool) []int {
+	// tails[k] holds the index of the smallest tail of the subsequences of length k+1.
+	var tails []int
+	// prev[i] holds the index of the previous element in the subsequence that ends in i.
+	prev := make([]int, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		v := s.Index(i)
+		k := sort.Search(len(tails), func(k int) bool {
+			cmp := fns.compare(s.Index(tails[k]), v)
+			return cmp > 0 || (strict && cmp == 0)
+		})
+		if k > 0 {
+			prev[i] = tails[k-1]
+		} else {
+			prev[i] = -1
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	indices := make([]int, len(tails))
+	if len(tails) > 0 {
+		for i, k := tails[len(tails)-1], len(tails)-1; k >= 0; i, k = prev[i], k-1 {
+			indices[k] = i
+		}
+	}
+	return indices
+}
+
+// complementIndices returns the indices in [0, n) that are not in the given increasing indices.
+func complementIndices(indices []int, n int) []int {
+	var complement []int
+	j := 0
+	for i := 0; i < n; i++ {
+		if j < len(indices) && indices[j] == i {
+			j++
+			continue
+		}
+		complement = append(complement, i)
+	}
+	return complement
+}
+
+// numValue returns a float representation of a number value, or nil if the value is not a number.
+func numValue(v reflect.Value) *float64 {
+	var f float64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = v.Float()
+	default:
+		return nil
+	}
+	return &f
+}
+
+// fromFloat returns a value of the given number type from a float. Integers are rounded.
+func fromFloat(tp reflect.Type, f float64) reflect.Value {
+	v := reflect.New(tp).Elem()
+	switch tp.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(math.Round(f)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(math.Round(f)))
+	default:
+		v.SetFloat(f)
+	}
+	return v
+}
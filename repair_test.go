@@ -0,0 +1,87 @@
+package order
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var floatFn = By(func(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+})
+
+func TestMakeSorted_isotonic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []float64
+		want  []float64
+	}{
+		{slice: []float64{}, want: []float64{}},
+		{slice: []float64{1, 2, 3}, want: []float64{1, 2, 3}},
+		{slice: []float64{1, 3, 2, 4}, want: []float64{1, 2.5, 2.5, 4}},
+		{slice: []float64{4, 3, 2, 1}, want: []float64{2.5, 2.5, 2.5, 2.5}},
+		{slice: []float64{1, 5, 2, 3, 6}, want: []float64{1, 10.0 / 3, 10.0 / 3, 10.0 / 3, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			got, dropped := floatFn.MakeSorted(tt.slice, RepairIsotonic)
+			assert.Equal(t, tt.want, got)
+			assert.Nil(t, dropped)
+			assert.True(t, floatFn.IsSorted(got))
+		})
+	}
+}
+
+func TestMakeSorted_isotonicInts(t *testing.T) {
+	t.Parallel()
+
+	got, _ := intFn.MakeSorted([]int{1, 4, 1, 5}, RepairIsotonic)
+	assert.Equal(t, []int{1, 3, 3, 5}, got)
+
+	// Reversed order makes the slice decreasing.
+	got, _ = intFn.Reversed().MakeSorted([]int{5, 1, 4, 0}, RepairIsotonic)
+	assert.Equal(t, []int{5, 3, 3, 0}, got)
+
+	// Not numbers.
+	assert.Panics(t, func() { stringFn.MakeSorted([]string{"b", "a"}, RepairIsotonic) })
+}
+
+func TestMakeSorted_drop(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice       []int
+		want        []int
+		wantDropped []int
+	}{
+		{slice: []int{}, want: []int{}, wantDropped: nil},
+		{slice: []int{1, 2, 2, 3}, want: []int{1, 2, 2, 3}, wantDropped: nil},
+		{slice: []int{1, 5, 2, 3}, want: []int{1, 2, 3}, wantDropped: []int{1}},
+		{slice: []int{3, 2, 1}, want: []int{1}, wantDropped: []int{0, 1}},
+		{slice: []int{1, 2, 10, 3, 4, 0, 5}, want: []int{1, 2, 3, 4, 5}, wantDropped: []int{2, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			got, gotDropped := intFn.MakeSorted(tt.slice, RepairDrop)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantDropped, gotDropped)
+		})
+	}
+}
+
+func TestMakeSorted_unknownStrategy(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { intFn.MakeSorted([]int{}, Repair(-1)) })
+}
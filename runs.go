@@ -0,0 +1,95 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Compact removes consecutive equal elements, according to the comparison function, from the given
+// slice, keeping the first element of every run of equal elements. The slice is modified in place,
+// and the returned value is the slice, of the given slice type, truncated to the compacted length.
+// The removed elements are moved after the returned length in an unspecified order. To remove all
+// duplicates, the slice should be sorted first.
+func (fns Fns) Compact(slice interface{}) interface{} {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	if s.Len() == 0 {
+		return s.Interface()
+	}
+
+	// Move each first element of a run right after the last kept element.
+	n := 1
+	for i := 1; i < s.Len(); i++ {
+		if fns.compare(s.Index(n-1), s.Index(i)) != 0 {
+			s.Swap(n, i)
+			n++
+		}
+	}
+	return s.Slice(0, n).Interface()
+}
+
+// Run is a group of consecutive equal elements in a slice.
+type Run struct {
+	// Start is the index of the first element of the run.
+	Start int
+	// Len is the number of elements in the run.
+	Len int
+	// Value is the first element of the run.
+	Value interface{}
+}
+
+// Runs returns the runs of consecutive equal elements, according to the comparison function, of the
+// given slice. For a sorted slice, it returns the frequency of each distinct value.
+func (fns Fns) Runs(slice interface{}) []Run {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+
+	var runs []Run
+	for i := 0; i < s.Len(); {
+		end := fns.runEnd(s, i)
+		runs = append(runs, Run{Start: i, Len: end - i, Value: s.Index(i).Interface()})
+		i = end
+	}
+	return runs
+}
+
+// Boundaries returns the indices i of the given slice in which slice[i] is not equal to
+// slice[i-1], according to the comparison function. For a sorted slice, these are the indices in
+// which the compared key changes, which allows iterating over groups of equal elements without
+// allocating them:
+//
+// 	start := 0
+// 	for _, end := range append(fns.Boundaries(slice), len(slice)) {
+// 		group := slice[start:end]
+// 		start = end
+// 	}
+func (fns Fns) Boundaries(slice interface{}) []int {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+
+	var boundaries []int
+	for i := 1; i < s.Len(); i++ {
+		if fns.compare(s.Index(i-1), s.Index(i)) != 0 {
+			boundaries = append(boundaries, i)
+		}
+	}
+	return boundaries
+}
+
+// Group sorts the given slice in place according to the comparison functions, and splits it to
+// groups of elements that are equal according to the first n comparison functions. Each group is a
+// sub-slice of the given slice type, that shares the given slice memory. For example, a slice of
+// persons ordered by name and then by age, can be grouped by name with n = 1, where each group is
+// sorted by age. It panics if n is not in the range [1, len(fns)].
+func (fns Fns) Group(slice interface{}, n int) []interface{} {
+	if n < 1 || n > len(fns) {
+		panic(fmt.Sprintf("n value %d out of bounds: [1, %d]", n, len(fns)))
+	}
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	fns.Sort(s.Interface())
+
+	var groups []interface{}
+	for i := 0; i < s.Len(); {
+		end := fns[:n].runEnd(s, i)
+		groups = append(groups, s.Slice(i, end).Interface())
+		i = end
+	}
+	return groups
+}
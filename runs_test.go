@@ -0,0 +1,134 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  []int
+	}{
+		{slice: []int{}, want: []int{}},
+		{slice: []int{1}, want: []int{1}},
+		{slice: []int{1, 1, 1}, want: []int{1}},
+		{slice: []int{1, 2, 3}, want: []int{1, 2, 3}},
+		{slice: []int{1, 1, 2, 3, 3, 3, 4}, want: []int{1, 2, 3, 4}},
+		{slice: []int{1, 2, 1, 1, 2}, want: []int{1, 2, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			original := copySlice(tt.slice)
+			got := Compact(tt.slice)
+			assert.Equal(t, tt.want, got)
+			// Removed elements are kept after the compacted length.
+			assert.ElementsMatch(t, original, tt.slice)
+		})
+	}
+}
+
+func TestCompact_byField(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		name string
+		age  int
+	}
+	byName := By(func(a, b person) int { return strings.Compare(a.name, b.name) })
+
+	got := byName.Compact([]person{{"a", 1}, {"a", 2}, {"b", 1}})
+	assert.Equal(t, []person{{"a", 1}, {"b", 1}}, got)
+}
+
+func TestRuns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  []Run
+	}{
+		{slice: []int{}, want: nil},
+		{slice: []int{1}, want: []Run{{Start: 0, Len: 1, Value: 1}}},
+		{
+			slice: []int{1, 1, 2, 3, 3, 3},
+			want:  []Run{{Start: 0, Len: 2, Value: 1}, {Start: 2, Len: 1, Value: 2}, {Start: 3, Len: 3, Value: 3}},
+		},
+		{
+			slice: []int{1, 2, 1},
+			want:  []Run{{Start: 0, Len: 1, Value: 1}, {Start: 1, Len: 1, Value: 2}, {Start: 2, Len: 1, Value: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			assert.Equal(t, tt.want, Runs(tt.slice))
+		})
+	}
+}
+
+func TestBoundaries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  []int
+	}{
+		{slice: []int{}, want: nil},
+		{slice: []int{1}, want: nil},
+		{slice: []int{1, 1, 1}, want: nil},
+		{slice: []int{1, 2, 3}, want: []int{1, 2}},
+		{slice: []int{1, 1, 2, 3, 3, 3, 4}, want: []int{2, 3, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			assert.Equal(t, tt.want, Boundaries(tt.slice))
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		name string
+		age  int
+	}
+	fns := By(
+		func(a, b person) int { return strings.Compare(a.name, b.name) },
+		func(a, b person) int { return a.age - b.age },
+	)
+
+	persons := []person{{"b", 2}, {"a", 3}, {"b", 1}, {"a", 3}, {"c", 1}}
+
+	got := fns.Group(persons, 1)
+	want := []interface{}{
+		[]person{{"a", 3}, {"a", 3}},
+		[]person{{"b", 1}, {"b", 2}},
+		[]person{{"c", 1}},
+	}
+	assert.Equal(t, want, got)
+
+	got = fns.Group(persons, 2)
+	want = []interface{}{
+		[]person{{"a", 3}, {"a", 3}},
+		[]person{{"b", 1}},
+		[]person{{"b", 2}},
+		[]person{{"c", 1}},
+	}
+	assert.Equal(t, want, got)
+
+	// Empty slice.
+	assert.Empty(t, fns.Group([]person{}, 1))
+
+	// Out of bounds n.
+	assert.Panics(t, func() { fns.Group(persons, 0) })
+	assert.Panics(t, func() { fns.Group(persons, 3) })
+}
@@ -3,6 +3,7 @@ package order
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/posener/order/internal/reflectutil"
 )
@@ -18,12 +19,23 @@ import (
 // This function will panic if k is out of the bounds of slice.
 func (fns Fns) Select(slice interface{}, k int) {
 	s := fns.mustSlice(reflect.ValueOf(slice))
+	fns.bindSlice(s).selectK(s, k, 1)
+}
+
+// selectK applies select-k algorithm on the given slice, where the partition step of large slices
+// is done by the given number of workers.
+func (fns Fns) selectK(s reflectutil.Slice, k, workers int) {
 	if k < 0 || k >= s.Len() {
 		panic(fmt.Sprintf("k value %d out of bounds: [0, %d)", k, s.Len()))
 	}
 	for {
 		fns.pivot(s)
-		pivot := fns.partition(s, 0)
+		var pivot int
+		if w := parallelWorkers(s.Len(), workers); w > 1 {
+			pivot = fns.partitionParallel(s, 0, w)
+		} else {
+			pivot = fns.partition(s, 0)
+		}
 		switch {
 		case pivot == k:
 			return
@@ -36,6 +48,61 @@ func (fns Fns) Select(slice interface{}, k int) {
 	}
 }
 
+// EqualFrequencyBuckets returns k-1 boundary values that split the given slice to k buckets, such
+// that each bucket holds about the same number of elements. The i'th boundary is the element with
+// rank i*n/k in the sorted slice, such that bucket 0 holds the elements that are less than the first
+// boundary, and the last bucket holds the elements that are greater than or equal to the last
+// boundary. When there are many equal elements, the buckets might not be balanced. The given slice is
+// not modified. It returns nil for an empty slice, and panics if k is not positive.
+func (fns Fns) EqualFrequencyBuckets(slice interface{}, k int) []interface{} {
+	if k <= 0 {
+		panic(fmt.Sprintf("k value %d should be positive", k))
+	}
+	s := fns.mustSlice(reflect.ValueOf(slice)).Copy()
+	n := s.Len()
+	if n == 0 {
+		return nil
+	}
+
+	boundaries := make([]interface{}, 0, k-1)
+	prev := 0
+	for i := 1; i < k; i++ {
+		rank := i * n / k
+		// Elements after the previous rank are greater than or equal to it, so only this part of
+		// the slice should be partitioned by the current rank.
+		fns.Select(s.Slice(prev, n).Interface(), rank-prev)
+		boundaries = append(boundaries, s.Index(rank).Interface())
+		prev = rank
+	}
+	return boundaries
+}
+
+// Bucketize returns the bucket index of each element of the given slice, according to the given
+// sorted boundaries. With n boundaries there are n+1 buckets: bucket 0 holds the elements that are
+// less than the first boundary, bucket i holds the elements that are greater than or equal to the
+// i-1'th boundary and less than the i'th boundary, and bucket n holds the elements that are greater
+// than or equal to the last boundary. The boundaries are a slice of values of type T, or a slice of
+// interfaces that hold values of type T, such as the result of EqualFrequencyBuckets. It panics if
+// the boundaries are not sorted.
+func (fns Fns) Bucketize(slice, boundaries interface{}) []int {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	bs := mustSlice(reflect.ValueOf(boundaries))
+	b := make([]reflect.Value, bs.Len())
+	for i := range b {
+		b[i] = fns.mustValue(unwrapInterface(bs.Index(i)))
+		if i > 0 && fns.compare(b[i-1], b[i]) > 0 {
+			panic(fmt.Sprintf("boundaries are not sorted: %v", boundaries))
+		}
+	}
+
+	buckets := make([]int, s.Len())
+	for i := range buckets {
+		v := s.Index(i)
+		buckets[i] = sort.Search(len(b), func(j int) bool { return fns.compare(b[j], v) > 0 })
+	}
+	return buckets
+}
+
 // pivot puts the median-of-medians in the index 0 of the slice.
 func (fns Fns) pivot(s reflectutil.Slice) {
 	const size = 5
@@ -75,21 +142,26 @@ func (fns Fns) partition(s reflectutil.Slice, p int) int {
 
 	// Put the pivot at the end of the slice.
 	s.Swap(p, n-1)
-	pivot := s.Index(n - 1)
+	cursor := fns.partitionPivot(s.Slice(0, n-1), s.Index(n-1))
 
+	// Move the pivot value back to the cursor location.
+	s.Swap(cursor, n-1)
+
+	return cursor
+}
+
+// partitionPivot moves all the values that are smaller than the pivot value to the beginning of the
+// slice, and returns their number.
+func (fns Fns) partitionPivot(s reflectutil.Slice, pivot reflect.Value) int {
 	// Iterate over the slice and move to cursor location all values that are smaller than the pivot
 	// value.
 	cursor := 0
-	for i := 0; i < n-1; i++ {
+	for i := 0; i < s.Len(); i++ {
 		if fns.compare(s.Index(i), pivot) < 0 {
 			s.Swap(cursor, i)
 			cursor++
 		}
 	}
-
-	// Move the pivot value back to the cursor location.
-	s.Swap(cursor, n-1)
-
 	return cursor
 }
 
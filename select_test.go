@@ -103,6 +103,67 @@ func TestSelect_pivot(t *testing.T) {
 	}
 }
 
+func TestEqualFrequencyBuckets(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{9, 3, 7, 1, 5, 8, 2, 6, 4, 0}
+	original := copySlice(slice)
+
+	tests := []struct {
+		k    int
+		want []interface{}
+	}{
+		{k: 1, want: []interface{}{}},
+		{k: 2, want: []interface{}{5}},
+		{k: 3, want: []interface{}{3, 6}},
+		{k: 5, want: []interface{}{2, 4, 6, 8}},
+		{k: 10, want: []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.k), func(t *testing.T) {
+			assert.Equal(t, tt.want, EqualFrequencyBuckets(slice, tt.k))
+			assert.Equal(t, original, slice)
+		})
+	}
+
+	assert.Nil(t, EqualFrequencyBuckets([]int{}, 2))
+	assert.Panics(t, func() { EqualFrequencyBuckets(slice, 0) })
+}
+
+func TestBucketize(t *testing.T) {
+	t.Parallel()
+
+	slice := []int{0, 1, 5, 9, 10, 11, 100}
+	tests := []struct {
+		boundaries interface{}
+		want       []int
+	}{
+		{boundaries: []int{}, want: []int{0, 0, 0, 0, 0, 0, 0}},
+		{boundaries: []int{10}, want: []int{0, 0, 0, 0, 1, 1, 1}},
+		{boundaries: []int{1, 10, 50}, want: []int{0, 1, 1, 1, 2, 2, 3}},
+		{boundaries: []int{5, 5}, want: []int{0, 0, 2, 2, 2, 2, 2}},
+		{boundaries: []interface{}{1, 10}, want: []int{0, 1, 1, 1, 2, 2, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.boundaries), func(t *testing.T) {
+			assert.Equal(t, tt.want, Bucketize(slice, tt.boundaries))
+		})
+	}
+
+	// With equal frequency buckets.
+	values := []int{9, 3, 7, 1, 5, 8, 2, 6, 4, 0}
+	assert.Equal(t,
+		[]int{1, 0, 1, 0, 1, 1, 0, 1, 0, 0},
+		Bucketize(values, EqualFrequencyBuckets(values, 2)))
+
+	assert.Equal(t, []int{}, Bucketize([]int{}, []int{1}))
+	assert.Panics(t, func() { Bucketize(slice, []int{2, 1}) })
+	assert.Panics(t, func() { Bucketize(slice, []string{"a"}) })
+	assert.Panics(t, func() { Bucketize(slice, []interface{}{"a"}) })
+}
+
 func copySlice(s []int) []int {
 	cp := make([]int, len(s))
 	copy(cp, s)
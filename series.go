@@ -0,0 +1,78 @@
+package order
+
+import (
+	"reflect"
+)
+
+// Deltas classifies each pair of adjacent elements in the given slice according to the comparison
+// function. The i'th returned value is 1 if slice[i+1] > slice[i], -1 if slice[i+1] < slice[i],
+// and 0 if they are equal. For a slice with less than two elements, it returns nil.
+func (fns Fns) Deltas(slice interface{}) []int {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	if s.Len() < 2 {
+		return nil
+	}
+	deltas := make([]int, s.Len()-1)
+	for i := range deltas {
+		deltas[i] = sign(fns.compare(s.Index(i+1), s.Index(i)))
+	}
+	return deltas
+}
+
+// MaxIncreasingStreak returns the start index and the length of the longest run of consecutive
+// elements in the given slice that are strictly increasing according to the comparison function. If
+// there are several such runs, the first of them is returned. It returns (-1, 0) for an empty slice.
+func (fns Fns) MaxIncreasingStreak(slice interface{}) (start, length int) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	if s.Len() == 0 {
+		return -1, 0
+	}
+	start, length = 0, 1
+	current := 0
+	for i := 1; i < s.Len(); i++ {
+		if fns.compare(s.Index(i-1), s.Index(i)) >= 0 {
+			current = i
+		}
+		if i-current+1 > length {
+			start, length = current, i-current+1
+		}
+	}
+	return start, length
+}
+
+// LongestSortedRun returns the range [start, end) of the longest run of consecutive elements in the
+// given slice that is sorted (non-decreasing) according to the comparison function. If there are
+// several such runs, the first of them is returned. It returns (0, 0) for an empty slice.
+func (fns Fns) LongestSortedRun(slice interface{}) (start, end int) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	current := 0
+	for i := 0; i < s.Len(); i++ {
+		if i > 0 && fns.compare(s.Index(i-1), s.Index(i)) > 0 {
+			current = i
+		}
+		if i+1-current > end-start {
+			start, end = current, i+1
+		}
+	}
+	return start, end
+}
+
+// LIS returns the increasing indices of a longest subsequence of the given slice that is strictly
+// increasing according to the comparison function. The elements in the subsequence are not
+// necessarily consecutive. The computation takes O(n*log(n)) time, using patience sorting. To get a
+// longest non-decreasing subsequence, see MakeSorted with RepairDrop.
+func (fns Fns) LIS(slice interface{}) []int {
+	return fns.longestSubsequence(fns.mustSlice(reflect.ValueOf(slice)), true)
+}
+
+// sign returns the sign of the given value.
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,104 @@
+package order
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltas(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  []int
+	}{
+		{slice: []int{}, want: nil},
+		{slice: []int{1}, want: nil},
+		{slice: []int{1, 5, 5, 2, 3}, want: []int{1, 0, -1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			assert.Equal(t, tt.want, Deltas(tt.slice))
+		})
+	}
+}
+
+func TestMaxIncreasingStreak(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice      []int
+		wantStart  int
+		wantLength int
+	}{
+		{slice: []int{}, wantStart: -1, wantLength: 0},
+		{slice: []int{1}, wantStart: 0, wantLength: 1},
+		{slice: []int{3, 2, 1}, wantStart: 0, wantLength: 1},
+		{slice: []int{1, 2, 3}, wantStart: 0, wantLength: 3},
+		{slice: []int{1, 2, 2, 3, 4, 0}, wantStart: 2, wantLength: 3},
+		{slice: []int{5, 1, 2, 0, 3}, wantStart: 1, wantLength: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			gotStart, gotLength := MaxIncreasingStreak(tt.slice)
+			assert.Equal(t, tt.wantStart, gotStart)
+			assert.Equal(t, tt.wantLength, gotLength)
+		})
+	}
+}
+
+func TestLongestSortedRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice              []int
+		wantStart, wantEnd int
+	}{
+		{slice: []int{}, wantStart: 0, wantEnd: 0},
+		{slice: []int{1}, wantStart: 0, wantEnd: 1},
+		{slice: []int{3, 2, 1}, wantStart: 0, wantEnd: 1},
+		{slice: []int{1, 2, 2, 3}, wantStart: 0, wantEnd: 4},
+		{slice: []int{5, 1, 2, 2, 0, 3}, wantStart: 1, wantEnd: 4},
+		{slice: []int{2, 1, 3, 0, 4, 5, 6}, wantStart: 3, wantEnd: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			gotStart, gotEnd := LongestSortedRun(tt.slice)
+			assert.Equal(t, tt.wantStart, gotStart)
+			assert.Equal(t, tt.wantEnd, gotEnd)
+		})
+	}
+}
+
+func TestLIS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  []int
+	}{
+		{slice: []int{}, want: []int{}},
+		{slice: []int{1}, want: []int{0}},
+		{slice: []int{3, 2, 1}, want: []int{2}},
+		{slice: []int{1, 2, 2, 3}, want: []int{0, 2, 3}},
+		{slice: []int{0, 8, 4, 12, 2, 10, 6, 14, 1, 9}, want: []int{0, 4, 6, 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			got := LIS(tt.slice)
+			assert.Equal(t, tt.want, got)
+			// The subsequence is strictly increasing.
+			var values []int
+			for _, i := range got {
+				values = append(values, tt.slice[i])
+			}
+			assert.True(t, intFn.IsStrictSorted(values))
+		})
+	}
+}
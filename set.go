@@ -0,0 +1,142 @@
+package order
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Set is a collection of unique values, where values are equal if the comparison functions return
+// zero for them. The values are kept sorted. A Set is not safe for concurrent use.
+type Set struct {
+	list *SortedList
+}
+
+// NewSet returns an empty Set of values of type T, ordered by the given comparison functions.
+func NewSet(fns Fns) *Set {
+	return &Set{list: NewSortedList(fns)}
+}
+
+// Add adds a value to the set. It returns false if the set already contains an equal value, in
+// which case the set is not modified. It panics if the value is not of type T.
+func (s *Set) Add(value interface{}) bool {
+	if s.list.Contains(value) {
+		return false
+	}
+	s.list.Insert(value)
+	return true
+}
+
+// Has returns whether the set contains a value that is equal to the given value.
+func (s *Set) Has(value interface{}) bool {
+	return s.list.Contains(value)
+}
+
+// Remove removes the value that is equal to the given value from the set. It returns whether a
+// value was removed.
+func (s *Set) Remove(value interface{}) bool {
+	return s.list.Delete(value)
+}
+
+// Len returns the number of values in the set.
+func (s *Set) Len() int {
+	return s.list.Len()
+}
+
+// Range calls f sequentially for each value in the set, in order. If f returns false, range stops
+// the iteration. The set should not be modified during the iteration.
+func (s *Set) Range(f func(value interface{}) bool) {
+	s.list.Range(func(_ int, value interface{}) bool { return f(value) })
+}
+
+// AppendTo appends the values of the set, in order, to the slice that dstPtr points to. See
+// SortedList.AppendTo.
+func (s *Set) AppendTo(dstPtr interface{}) {
+	s.list.AppendTo(dstPtr)
+}
+
+// CopyRange sets the slice that dstPtr points to, to the values of the set that are greater or
+// equal to lo, and less than hi, in order. See SortedList.CopyRange.
+func (s *Set) CopyRange(dstPtr interface{}, lo, hi interface{}) {
+	s.list.CopyRange(dstPtr, lo, hi)
+}
+
+// Union returns a new set, with the comparison functions of s, that contains the values that are in
+// s or in other. For values that are in both sets, the value of s is taken. The values of other are
+// ordered by the comparison functions of s, such that the sets may have different comparison
+// functions. It panics if the values of other are not of type T.
+func (s *Set) Union(other *Set) *Set {
+	return s.merge(other, true)
+}
+
+// Intersect returns a new set, with the comparison functions of s, that contains the values of s
+// that are also in other. The values of other are ordered by the comparison functions of s, such
+// that the sets may have different comparison functions. It panics if the values of other are not
+// of type T.
+func (s *Set) Intersect(other *Set) *Set {
+	return s.merge(other, false)
+}
+
+// merge merges the values of the sets into a new set. Values that are in both sets are always
+// taken, and values that are only in one of the sets are taken if union is true.
+func (s *Set) merge(other *Set, union bool) *Set {
+	fns := s.list.fns
+	a, b := s.list.values, orderedUnique(fns, other.list.values)
+	var values []interface{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		cmp := fns.compare(reflect.ValueOf(a[i]), reflect.ValueOf(b[j]))
+		switch {
+		case cmp < 0: // a[i] < b[j]
+			if union {
+				values = append(values, a[i])
+			}
+			i++
+		case cmp > 0: // a[i] > b[j]
+			if union {
+				values = append(values, b[j])
+			}
+			j++
+		default: // a[i] == b[j]
+			values = append(values, a[i])
+			i++
+			j++
+		}
+	}
+	if union {
+		values = append(values, a[i:]...)
+		values = append(values, b[j:]...)
+	}
+	return &Set{list: &SortedList{fns: fns, values: values}}
+}
+
+// orderedUnique returns the given values sorted by the given comparison functions, where only the
+// first of equal values is kept. The values of another set might be ordered by other comparison
+// functions, or contain values that are equal by the given functions. The given values are not
+// modified, and they are returned as is if they are already strictly increasing. It panics if the
+// values are not of type T.
+func orderedUnique(fns Fns, values []interface{}) []interface{} {
+	rvs := make([]reflect.Value, len(values))
+	ordered := true
+	for i, v := range values {
+		rvs[i] = fns.mustValue(reflect.ValueOf(v))
+		if i > 0 && fns.compare(rvs[i-1], rvs[i]) >= 0 {
+			ordered = false
+		}
+	}
+	if ordered {
+		return values
+	}
+	idx := make([]int, len(values))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return fns.compare(rvs[idx[i]], rvs[idx[j]]) < 0 })
+	unique := make([]interface{}, 0, len(values))
+	for k, i := range idx {
+		if k > 0 && fns.compare(rvs[idx[k-1]], rvs[i]) == 0 {
+			continue
+		}
+		unique = append(unique, values[i])
+	}
+	return unique
+}
@@ -0,0 +1,92 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	s := NewSet(intFn)
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Has(1))
+
+	assert.True(t, s.Add(2))
+	assert.True(t, s.Add(1))
+	assert.False(t, s.Add(2))
+	assert.True(t, s.Add(int8(3)))
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []interface{}{1, 2, int8(3)}, setValues(s))
+
+	assert.True(t, s.Has(3))
+	assert.False(t, s.Has(4))
+
+	assert.True(t, s.Remove(2))
+	assert.False(t, s.Remove(2))
+	assert.Equal(t, []interface{}{1, int8(3)}, setValues(s))
+}
+
+func TestSet_unionIntersect(t *testing.T) {
+	t.Parallel()
+
+	a := newIntSet(1, 3, 5, 7)
+	b := newIntSet(2, 3, 4, 7, 8, 9)
+
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 7, 8, 9}, setValues(a.Union(b)))
+	assert.Equal(t, []interface{}{3, 7}, setValues(a.Intersect(b)))
+
+	// Operands are not modified.
+	assert.Equal(t, []interface{}{1, 3, 5, 7}, setValues(a))
+	assert.Equal(t, []interface{}{2, 3, 4, 7, 8, 9}, setValues(b))
+
+	// Empty sets.
+	empty := NewSet(intFn)
+	assert.Equal(t, setValues(a), setValues(a.Union(empty)))
+	assert.Equal(t, setValues(a), setValues(empty.Union(a)))
+	assert.Equal(t, 0, a.Intersect(empty).Len())
+
+	// Sets with other comparison functions.
+	desc := NewSet(intFn.Reversed())
+	for _, v := range []int{9, 8, 7, 4, 3, 2} {
+		desc.Add(v)
+	}
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 7, 8, 9}, setValues(a.Union(desc)))
+	assert.Equal(t, []interface{}{3, 7}, setValues(a.Intersect(desc)))
+	assert.Equal(t, []interface{}{9, 8, 7, 4, 3, 2}, setValues(desc))
+
+	// Values that are distinct in other, but equal by the comparison functions of s.
+	mod := NewSet(By(func(a, b int) int { return a%10 - b%10 }))
+	tens := NewSet(intFn)
+	for _, v := range []int{23, 3, 13, 1} {
+		tens.Add(v)
+	}
+	for _, v := range []int{3, 4} {
+		mod.Add(v)
+	}
+	assert.Equal(t, []interface{}{1, 3, 4}, setValues(mod.Union(tens)))
+	assert.Equal(t, []interface{}{3}, setValues(mod.Intersect(tens)))
+
+	// Values of other type.
+	strings := NewSet(stringFn)
+	strings.Add("a")
+	assert.Panics(t, func() { a.Union(strings) })
+}
+
+func newIntSet(values ...int) *Set {
+	s := NewSet(intFn)
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func setValues(s *Set) []interface{} {
+	var values []interface{}
+	s.Range(func(v interface{}) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
@@ -0,0 +1,161 @@
+package order
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// skipListMaxLevel is the maximal number of levels of a SkipList.
+const skipListMaxLevel = 32
+
+// SkipList is a collection of values that are kept sorted according to comparison functions. It is
+// backed by a skip list, such that lookups, insertions and deletions take O(log n) expected time.
+// It is an alternative to SortedList for write heavy workloads, which does not support access by
+// index. A SkipList is not safe for concurrent use.
+type SkipList struct {
+	fns Fns
+	// head is a sentinel node that points to the first node in each level.
+	head skipNode
+	// level is the number of levels that are in use.
+	level int
+	len   int
+	rnd   *rand.Rand
+}
+
+type skipNode struct {
+	value interface{}
+	// next holds the next node in each of the levels of the node.
+	next []*skipNode
+}
+
+// NewSkipList returns an empty SkipList of values of type T, ordered by the given comparison
+// functions.
+func NewSkipList(fns Fns) *SkipList {
+	return &SkipList{
+		fns:   fns,
+		head:  skipNode{next: make([]*skipNode, skipListMaxLevel)},
+		level: 1,
+		rnd:   rand.New(rand.NewSource(1)),
+	}
+}
+
+// Insert inserts a value into the list. The value is inserted after all the values that are equal
+// to it. It panics if the value is not of type T.
+func (l *SkipList) Insert(value interface{}) {
+	update := l.predecessors(l.fns.mustValue(reflect.ValueOf(value)), true)
+	level := l.randomLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = &l.head
+		}
+		l.level = level
+	}
+	node := &skipNode{value: value, next: make([]*skipNode, level)}
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	l.len++
+}
+
+// Delete deletes the first value that is equal to the given value from the list. It returns whether
+// a value was deleted.
+func (l *SkipList) Delete(value interface{}) bool {
+	v := l.fns.mustValue(reflect.ValueOf(value))
+	update := l.predecessors(v, false)
+	node := update[0].next[0]
+	if node == nil || l.compare(node, v) != 0 {
+		return false
+	}
+	for i := range node.next {
+		update[i].next[i] = node.next[i]
+	}
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+	l.len--
+	return true
+}
+
+// Contains returns whether the list contains a value that is equal to the given value.
+func (l *SkipList) Contains(value interface{}) bool {
+	v := l.fns.mustValue(reflect.ValueOf(value))
+	node := &l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && l.compare(node.next[i], v) < 0 {
+			node = node.next[i]
+		}
+	}
+	node = node.next[0]
+	return node != nil && l.compare(node, v) == 0
+}
+
+// Len returns the number of values in the list.
+func (l *SkipList) Len() int {
+	return l.len
+}
+
+// Range calls f sequentially for each value of the list, in order. If f returns false, range stops
+// the iteration. The list should not be modified during the iteration.
+func (l *SkipList) Range(f func(value interface{}) bool) {
+	for node := l.head.next[0]; node != nil; node = node.next[0] {
+		if !f(node.value) {
+			return
+		}
+	}
+}
+
+// AppendTo appends the values of the list, in order, to the slice that dstPtr points to. See
+// SortedList.AppendTo.
+func (l *SkipList) AppendTo(dstPtr interface{}) {
+	w := newSliceWriter(dstPtr, false, l.len)
+	for node := l.head.next[0]; node != nil; node = node.next[0] {
+		w.write(node.value)
+	}
+	w.close()
+}
+
+// CopyRange sets the slice that dstPtr points to, to the values of the list that are greater or
+// equal to lo, and less than hi, in order. See SortedList.CopyRange.
+func (l *SkipList) CopyRange(dstPtr interface{}, lo, hi interface{}) {
+	h := l.fns.mustValue(reflect.ValueOf(hi))
+	w := newSliceWriter(dstPtr, true, 0)
+	node := l.predecessors(l.fns.mustValue(reflect.ValueOf(lo)), false)[0].next[0]
+	for ; node != nil && l.compare(node, h) < 0; node = node.next[0] {
+		w.write(node.value)
+	}
+	w.close()
+}
+
+// predecessors returns, for each level, the last node that is less than v, or that is less than or
+// equal to v if after is true.
+func (l *SkipList) predecessors(v reflect.Value, after bool) []*skipNode {
+	update := make([]*skipNode, skipListMaxLevel)
+	node := &l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			cmp := l.compare(node.next[i], v)
+			if cmp > 0 || (cmp == 0 && !after) {
+				break
+			}
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+	return update
+}
+
+// compare compares the value of a node with v.
+func (l *SkipList) compare(node *skipNode, v reflect.Value) int {
+	return l.fns.compare(reflect.ValueOf(node.value), v)
+}
+
+// randomLevel returns a random level for a new node, where each level has a quarter of the nodes of
+// the level below it.
+func (l *SkipList) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && l.rnd.Int63()&3 == 0 {
+		level++
+	}
+	return level
+}
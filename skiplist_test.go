@@ -0,0 +1,120 @@
+package order
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipList(t *testing.T) {
+	t.Parallel()
+
+	l := NewSkipList(intFn)
+	assert.Equal(t, 0, l.Len())
+	assert.False(t, l.Contains(1))
+	assert.False(t, l.Delete(1))
+
+	for _, v := range []int{3, 1, 2, 5, 2} {
+		l.Insert(v)
+	}
+	assert.Equal(t, []interface{}{1, 2, 2, 3, 5}, skipListValues(l))
+	assert.Equal(t, 5, l.Len())
+
+	assert.True(t, l.Contains(2))
+	assert.False(t, l.Contains(4))
+	assert.False(t, l.Contains(0))
+	assert.False(t, l.Contains(6))
+
+	assert.True(t, l.Delete(2))
+	assert.Equal(t, []interface{}{1, 2, 3, 5}, skipListValues(l))
+	assert.True(t, l.Delete(5))
+	assert.True(t, l.Delete(1))
+	assert.False(t, l.Delete(1))
+	assert.Equal(t, []interface{}{2, 3}, skipListValues(l))
+	assert.Equal(t, 2, l.Len())
+}
+
+func TestSkipList_insertStable(t *testing.T) {
+	t.Parallel()
+
+	intp := func(i int) *int { return &i }
+
+	values := []*int{intp(1), intp(1), intp(1)}
+	l := NewSkipList(intFn)
+	for _, v := range values {
+		l.Insert(v)
+	}
+	i := 0
+	l.Range(func(v interface{}) bool {
+		assert.True(t, v == values[i])
+		i++
+		return true
+	})
+
+	// Delete deletes the first of the equal values.
+	l.Delete(intp(1))
+	var got []interface{}
+	l.Range(func(v interface{}) bool {
+		got = append(got, v)
+		return false
+	})
+	assert.True(t, got[0] == values[1])
+}
+
+func TestSkipList_random(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(0))
+	l := NewSkipList(intFn)
+	var want []int
+	for i := 0; i < 1000; i++ {
+		v := rnd.Intn(100)
+		if rnd.Intn(3) == 0 {
+			j := sort.SearchInts(want, v)
+			found := j < len(want) && want[j] == v
+			if found {
+				want = append(want[:j], want[j+1:]...)
+			}
+			assert.Equal(t, found, l.Delete(v))
+		} else {
+			j := sort.SearchInts(want, v+1)
+			want = append(want[:j], append([]int{v}, want[j:]...)...)
+			l.Insert(v)
+		}
+	}
+	got := make([]int, 0, l.Len())
+	l.Range(func(v interface{}) bool {
+		got = append(got, v.(int))
+		return true
+	})
+	assert.Equal(t, want, got)
+}
+
+func skipListValues(l *SkipList) []interface{} {
+	var values []interface{}
+	l.Range(func(v interface{}) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+func TestSkipList_AppendTo(t *testing.T) {
+	t.Parallel()
+
+	l := NewSkipList(intFn)
+	for _, v := range []int{5, 1, 3, 3, 4} {
+		l.Insert(v)
+	}
+
+	var dst []int
+	l.AppendTo(&dst)
+	assert.Equal(t, []int{1, 3, 3, 4, 5}, dst)
+
+	l.CopyRange(&dst, 2, 5)
+	assert.Equal(t, []int{3, 3, 4}, dst)
+	l.CopyRange(&dst, 6, 10)
+	assert.Empty(t, dst)
+}
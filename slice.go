@@ -0,0 +1,79 @@
+package order
+
+import (
+	"reflect"
+
+	"github.com/posener/order/internal/reflectutil"
+)
+
+// Reverse reverses the order of the elements of the given slice in place. It can be used to present
+// an ascending sorted slice in a descending order without sorting it again.
+func Reverse(slice interface{}) {
+	reverse(mustSlice(reflect.ValueOf(slice)))
+}
+
+// Rotate rotates the elements of the given slice in place k positions to the left, such that the
+// element at index k becomes the first element. A negative k rotates the slice to the right.
+func Rotate(slice interface{}, k int) {
+	s := mustSlice(reflect.ValueOf(slice))
+	n := s.Len()
+	if n == 0 {
+		return
+	}
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	// Rotation by three reversals: reverse each of the two parts and then reverse the whole slice.
+	reverse(s.Slice(0, k))
+	reverse(s.Slice(k, n))
+	reverse(s)
+}
+
+// reverse reverses the order of the elements of the given slice.
+func reverse(s reflectutil.Slice) {
+	for i, j := 0, s.Len()-1; i < j; i, j = i+1, j-1 {
+		s.Swap(i, j)
+	}
+}
+
+// mustSlice panics if a given value is not a slice value.
+func mustSlice(slice reflect.Value) reflectutil.Slice {
+	s, err := reflectutil.NewSlice(slice)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// CompareSlices compares two slices lexicographically: the elements are compared in order, and the
+// result is the comparison of the first pair of elements that are not equal. If all the elements of
+// the shorter slice are equal to the elements of the longer one, the shorter slice is less. It
+// returns a value `c` as the comparison functions, such that whole slices can be ordered.
+func (fns Fns) CompareSlices(a, b interface{}) int {
+	sa := fns.mustSlice(reflect.ValueOf(a))
+	sb := fns.mustSlice(reflect.ValueOf(b))
+	for i := 0; i < sa.Len() && i < sb.Len(); i++ {
+		if cmp := fns.compare(sa.Index(i), sb.Index(i)); cmp != 0 {
+			return cmp
+		}
+	}
+	return sa.Len() - sb.Len()
+}
+
+// EqualSlices returns whether two slices have the same length, and each pair of their elements are
+// equal according to the comparison functions. Unlike reflect.DeepEqual, it uses the order notion of
+// equality, for example, elements that are compared by a key are equal if their keys are equal.
+func (fns Fns) EqualSlices(a, b interface{}) bool {
+	sa := fns.mustSlice(reflect.ValueOf(a))
+	sb := fns.mustSlice(reflect.ValueOf(b))
+	if sa.Len() != sb.Len() {
+		return false
+	}
+	for i := 0; i < sa.Len(); i++ {
+		if fns.compare(sa.Index(i), sb.Index(i)) != 0 {
+			return false
+		}
+	}
+	return true
+}
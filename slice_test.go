@@ -0,0 +1,122 @@
+package order
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		want  []int
+	}{
+		{slice: []int{}, want: []int{}},
+		{slice: []int{1}, want: []int{1}},
+		{slice: []int{1, 2}, want: []int{2, 1}},
+		{slice: []int{1, 2, 3}, want: []int{3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.slice), func(t *testing.T) {
+			Reverse(tt.slice)
+			assert.Equal(t, tt.want, tt.slice)
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		k    int
+		want []int
+	}{
+		{k: 0, want: []int{1, 2, 3, 4, 5}},
+		{k: 1, want: []int{2, 3, 4, 5, 1}},
+		{k: 3, want: []int{4, 5, 1, 2, 3}},
+		{k: 5, want: []int{1, 2, 3, 4, 5}},
+		{k: 7, want: []int{3, 4, 5, 1, 2}},
+		{k: -1, want: []int{5, 1, 2, 3, 4}},
+		{k: -7, want: []int{4, 5, 1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.k), func(t *testing.T) {
+			slice := []int{1, 2, 3, 4, 5}
+			Rotate(slice, tt.k)
+			assert.Equal(t, tt.want, slice)
+		})
+	}
+
+	// Empty slice.
+	assert.NotPanics(t, func() { Rotate([]int{}, 1) })
+}
+
+func TestSlice_invalidArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { Reverse(1) })
+	assert.Panics(t, func() { Rotate(1, 1) })
+}
+
+func TestCompareSlices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b []int
+		want int
+	}{
+		{a: []int{}, b: []int{}, want: 0},
+		{a: nil, b: []int{}, want: 0},
+		{a: []int{1, 2}, b: []int{1, 2}, want: 0},
+		{a: []int{1, 2}, b: []int{1, 3}, want: -1},
+		{a: []int{2}, b: []int{1, 3}, want: 1},
+		{a: []int{1}, b: []int{1, 0}, want: -1},
+		{a: []int{1, 0}, b: []int{1}, want: 1},
+		{a: []int{}, b: []int{0}, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.a, tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.want, sign(CompareSlices(tt.a, tt.b)))
+			assert.Equal(t, tt.want, sign(intFn.CompareSlices(tt.a, tt.b)))
+		})
+	}
+
+	// Slices of different convertible types.
+	assert.Equal(t, 0, intFn.CompareSlices([]int{0}, []*int{new(int)}))
+	// The shorter slice is less also in a reversed order.
+	assert.Equal(t, -1, sign(intFn.Reversed().CompareSlices([]int{1}, []int{1, 2})))
+	assert.Equal(t, 1, sign(intFn.Reversed().CompareSlices([]int{1}, []int{2})))
+}
+
+func TestEqualSlices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b []int
+		want bool
+	}{
+		{a: []int{}, b: []int{}, want: true},
+		{a: nil, b: []int{}, want: true},
+		{a: []int{1, 2}, b: []int{1, 2}, want: true},
+		{a: []int{1, 2}, b: []int{2, 1}, want: false},
+		{a: []int{1}, b: []int{1, 1}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.a, tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.want, EqualSlices(tt.a, tt.b))
+			assert.Equal(t, tt.want, intFn.EqualSlices(tt.a, tt.b))
+		})
+	}
+
+	// Equality is according to the comparison functions.
+	mod10 := By(func(a, b int) int { return a%10 - b%10 })
+	assert.True(t, mod10.EqualSlices([]int{1, 12}, []int{21, 2}))
+	assert.False(t, mod10.EqualSlices([]int{1, 12}, []int{21, 3}))
+}
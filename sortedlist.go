@@ -0,0 +1,176 @@
+package order
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// SortedList is a collection of values that are kept sorted according to comparison functions. It
+// is backed by a sorted slice, such that lookups take O(log n) time, and insertions and deletions
+// take O(n) time. A SortedList is not safe for concurrent use.
+type SortedList struct {
+	fns    Fns
+	values []interface{}
+}
+
+// NewSortedList returns an empty SortedList of values of type T, ordered by the given comparison
+// functions.
+func NewSortedList(fns Fns) *SortedList {
+	return &SortedList{fns: fns}
+}
+
+// Insert inserts a value into the list. The value is inserted after all the values that are equal
+// to it. It panics if the value is not of type T.
+func (l *SortedList) Insert(value interface{}) {
+	i := l.upperBound(l.fns.mustValue(reflect.ValueOf(value)))
+	l.values = append(l.values, nil)
+	copy(l.values[i+1:], l.values[i:])
+	l.values[i] = value
+}
+
+// Delete deletes the first value that is equal to the given value from the list. It returns whether
+// a value was deleted.
+func (l *SortedList) Delete(value interface{}) bool {
+	i := l.Search(value)
+	if i < 0 {
+		return false
+	}
+	l.deleteAt(i)
+	return true
+}
+
+// Contains returns whether the list contains a value that is equal to the given value.
+func (l *SortedList) Contains(value interface{}) bool {
+	return l.Search(value) >= 0
+}
+
+// Search returns the index of the first value that is equal to the given value, or -1 if there is
+// no such value.
+func (l *SortedList) Search(value interface{}) int {
+	v := l.fns.mustValue(reflect.ValueOf(value))
+	i := l.lowerBound(v)
+	if i == len(l.values) || l.compare(i, v) != 0 {
+		return -1
+	}
+	return i
+}
+
+// At returns the i'th value of the list. It panics if i is out of range.
+func (l *SortedList) At(i int) interface{} {
+	return l.values[i]
+}
+
+// Len returns the number of values in the list.
+func (l *SortedList) Len() int {
+	return len(l.values)
+}
+
+// Range calls f sequentially for each index and value of the list, in order. If f returns false,
+// range stops the iteration. The list should not be modified during the iteration.
+func (l *SortedList) Range(f func(i int, value interface{}) bool) {
+	for i, v := range l.values {
+		if !f(i, v) {
+			return
+		}
+	}
+}
+
+// AppendTo appends the values of the list, in order, to the slice that dstPtr points to. The slice
+// is grown at most once, and values are converted to its element type. It panics if dstPtr is not a
+// pointer to a slice, or if the values can't be converted to the slice element type.
+func (l *SortedList) AppendTo(dstPtr interface{}) {
+	w := newSliceWriter(dstPtr, false, len(l.values))
+	for _, v := range l.values {
+		w.write(v)
+	}
+	w.close()
+}
+
+// CopyRange sets the slice that dstPtr points to, to the values of the list that are greater or
+// equal to lo, and less than hi, in order. The backing array of the slice is reused if it has
+// enough capacity. It panics as AppendTo, or if lo or hi are not of type T.
+func (l *SortedList) CopyRange(dstPtr interface{}, lo, hi interface{}) {
+	start := l.lowerBound(l.fns.mustValue(reflect.ValueOf(lo)))
+	end := l.lowerBound(l.fns.mustValue(reflect.ValueOf(hi)))
+	if end < start {
+		end = start
+	}
+	w := newSliceWriter(dstPtr, true, end-start)
+	for _, v := range l.values[start:end] {
+		w.write(v)
+	}
+	w.close()
+}
+
+// deleteAt deletes the i'th value of the list.
+func (l *SortedList) deleteAt(i int) {
+	l.deleteRange(i, i+1)
+}
+
+// deleteRange deletes the values in the index range [i, j) of the list.
+func (l *SortedList) deleteRange(i, j int) {
+	n := copy(l.values[i:], l.values[j:])
+	// Allow garbage collection of the deleted values.
+	for k := i + n; k < len(l.values); k++ {
+		l.values[k] = nil
+	}
+	l.values = l.values[:i+n]
+}
+
+// compare compares the i'th value of the list with v.
+func (l *SortedList) compare(i int, v reflect.Value) int {
+	return l.fns.compare(reflect.ValueOf(l.values[i]), v)
+}
+
+// lowerBound returns the index of the first value that is not less than v.
+func (l *SortedList) lowerBound(v reflect.Value) int {
+	return sort.Search(len(l.values), func(i int) bool { return l.compare(i, v) >= 0 })
+}
+
+// upperBound returns the index of the first value that is greater than v.
+func (l *SortedList) upperBound(v reflect.Value) int {
+	return sort.Search(len(l.values), func(i int) bool { return l.compare(i, v) > 0 })
+}
+
+// sliceWriter writes values to the slice that a pointer points to.
+type sliceWriter struct {
+	ptr reflect.Value
+	s   reflect.Value
+	// src is the type of the last written value, and convert converts it to the slice element type.
+	src     reflect.Type
+	convert func(reflect.Value) reflect.Value
+}
+
+// newSliceWriter returns a writer to the slice that dstPtr points to, that has capacity for at least
+// n more values. If reset is true, the written values replace the current values of the slice. The
+// slice is updated when close is called.
+func newSliceWriter(dstPtr interface{}, reset bool, n int) *sliceWriter {
+	ptr := reflect.ValueOf(dstPtr)
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Slice {
+		panic(fmt.Sprintf("expected a pointer to a slice, got: %T", dstPtr))
+	}
+	s := ptr.Elem()
+	if reset {
+		s = s.Slice(0, 0)
+	}
+	if s.Cap()-s.Len() < n {
+		grown := reflect.MakeSlice(s.Type(), s.Len(), s.Len()+n)
+		reflect.Copy(grown, s)
+		s = grown
+	}
+	return &sliceWriter{ptr: ptr, s: s}
+}
+
+func (w *sliceWriter) write(value interface{}) {
+	v := reflect.ValueOf(value)
+	if v.Type() != w.src {
+		w.src = v.Type()
+		w.convert = mustConverter(w.s.Type().Elem(), w.src)
+	}
+	w.s = reflect.Append(w.s, w.convert(v))
+}
+
+func (w *sliceWriter) close() {
+	w.ptr.Elem().Set(w.s)
+}
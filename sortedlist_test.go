@@ -0,0 +1,124 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedList(t *testing.T) {
+	t.Parallel()
+
+	l := NewSortedList(intFn)
+	assert.Equal(t, 0, l.Len())
+	assert.False(t, l.Contains(1))
+	assert.False(t, l.Delete(1))
+
+	for _, v := range []int{3, 1, 2, 5, 2} {
+		l.Insert(v)
+	}
+	assert.Equal(t, []interface{}{1, 2, 2, 3, 5}, listValues(l))
+	assert.Equal(t, 5, l.Len())
+	assert.Equal(t, 1, l.At(0))
+	assert.Equal(t, 5, l.At(4))
+
+	assert.True(t, l.Contains(2))
+	assert.False(t, l.Contains(4))
+	assert.Equal(t, 1, l.Search(2))
+	assert.Equal(t, -1, l.Search(4))
+
+	assert.True(t, l.Delete(2))
+	assert.Equal(t, []interface{}{1, 2, 3, 5}, listValues(l))
+	assert.True(t, l.Delete(5))
+	assert.True(t, l.Delete(1))
+	assert.False(t, l.Delete(1))
+	assert.Equal(t, []interface{}{2, 3}, listValues(l))
+}
+
+func TestSortedList_insertStable(t *testing.T) {
+	t.Parallel()
+
+	intp := func(i int) *int { return &i }
+
+	one, two := intp(1), intp(1)
+	l := NewSortedList(intFn)
+	l.Insert(one)
+	l.Insert(two)
+	assert.True(t, l.At(0) == one)
+	assert.True(t, l.At(1) == two)
+}
+
+func TestSortedList_range(t *testing.T) {
+	t.Parallel()
+
+	l := NewSortedList(intFn)
+	for _, v := range []int{3, 1, 2} {
+		l.Insert(v)
+	}
+
+	var got []int
+	l.Range(func(i int, v interface{}) bool {
+		got = append(got, i, v.(int))
+		return i < 1
+	})
+	assert.Equal(t, []int{0, 1, 1, 2}, got)
+}
+
+func TestSortedList_invalidValue(t *testing.T) {
+	t.Parallel()
+
+	l := NewSortedList(intFn)
+	assert.Panics(t, func() { l.Insert("1") })
+	assert.Panics(t, func() { l.Contains("1") })
+	assert.Panics(t, func() { l.Delete("1") })
+	assert.Panics(t, func() { l.At(0) })
+}
+
+func listValues(l *SortedList) []interface{} {
+	var values []interface{}
+	l.Range(func(_ int, v interface{}) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+func TestSortedList_AppendTo(t *testing.T) {
+	t.Parallel()
+
+	l := NewSortedList(intFn)
+	for _, v := range []int{3, 1, 2} {
+		l.Insert(v)
+	}
+
+	dst := []int{0}
+	l.AppendTo(&dst)
+	assert.Equal(t, []int{0, 1, 2, 3}, dst)
+
+	// Values are converted to the slice element type.
+	var dst64 []int64
+	l.AppendTo(&dst64)
+	assert.Equal(t, []int64{1, 2, 3}, dst64)
+
+	assert.Panics(t, func() { l.AppendTo(dst) })
+	assert.Panics(t, func() { l.AppendTo(&[]string{}) })
+}
+
+func TestSortedList_CopyRange(t *testing.T) {
+	t.Parallel()
+
+	l := NewSortedList(intFn)
+	for _, v := range []int{5, 1, 3, 3, 4} {
+		l.Insert(v)
+	}
+
+	dst := make([]int, 1, 10)
+	l.CopyRange(&dst, 2, 5)
+	assert.Equal(t, []int{3, 3, 4}, dst)
+	assert.Equal(t, 10, cap(dst), "backing array should be reused")
+
+	l.CopyRange(&dst, 5, 2)
+	assert.Empty(t, dst)
+
+	assert.Panics(t, func() { l.CopyRange(&dst, "a", 5) })
+}
@@ -0,0 +1,73 @@
+package order
+
+import (
+	"reflect"
+)
+
+// Insert inserts a value into a sorted slice, such that the slice stays sorted according to the
+// comparison function. The value is inserted after all the elements that are equal to it. The value
+// is converted to the element type of the slice. Like the builtin append, the slice is modified in
+// place if it has enough capacity, and the returned value is the updated slice, of the given slice
+// type (or the pointed slice type if a pointer to a slice was given).
+func (fns Fns) Insert(slice, value interface{}) interface{} {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	v := fns.mustValue(reflect.ValueOf(value))
+	i := fns.upperBound(s, v)
+	v = mustConverter(s.T(), v.Type())(v)
+
+	// Grow the slice by one element and shift the elements after the insertion index.
+	result := reflect.Append(s.Value, reflect.Zero(s.T()))
+	reflect.Copy(result.Slice(i+1, result.Len()), result.Slice(i, result.Len()-1))
+	result.Index(i).Set(v)
+	return result.Interface()
+}
+
+// Remove removes the first element that is equal to the given value from a sorted slice. It returns
+// the updated slice, of the given slice type (or the pointed slice type if a pointer to a slice was
+// given), and whether an element was removed. The slice is modified in place.
+func (fns Fns) Remove(slice, value interface{}) (interface{}, bool) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	v := fns.mustValue(reflect.ValueOf(value))
+	i := fns.lowerBound(s, v)
+	if i == s.Len() || fns.compare(s.Index(i), v) != 0 {
+		return s.Interface(), false
+	}
+	return remove(s.Value, i, i+1).Interface(), true
+}
+
+// RemoveLast removes the last element that is equal to the given value from a sorted slice. See
+// Fns.Remove.
+func (fns Fns) RemoveLast(slice, value interface{}) (interface{}, bool) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	v := fns.mustValue(reflect.ValueOf(value))
+	i := fns.upperBound(s, v) - 1
+	if i < 0 || fns.compare(s.Index(i), v) != 0 {
+		return s.Interface(), false
+	}
+	return remove(s.Value, i, i+1).Interface(), true
+}
+
+// RemoveAll removes all the elements that are equal to the given value from a sorted slice. It
+// returns the updated slice, of the given slice type (or the pointed slice type if a pointer to a
+// slice was given), and the number of removed elements. The slice is modified in place.
+func (fns Fns) RemoveAll(slice, value interface{}) (interface{}, int) {
+	s := fns.mustSlice(reflect.ValueOf(slice))
+	v := fns.mustValue(reflect.ValueOf(value))
+	start, end := fns.lowerBound(s, v), fns.upperBound(s, v)
+	return remove(s.Value, start, end).Interface(), end - start
+}
+
+// remove removes the elements in the range [i, j) from the given slice by shifting the following
+// elements. The vacated elements at the end of the slice are set to zero values, such that they
+// could be garbage collected.
+func remove(s reflect.Value, i, j int) reflect.Value {
+	if i == j {
+		return s
+	}
+	n := reflect.Copy(s.Slice(i, s.Len()), s.Slice(j, s.Len()))
+	zero := reflect.Zero(s.Type().Elem())
+	for k := i + n; k < s.Len(); k++ {
+		s.Index(k).Set(zero)
+	}
+	return s.Slice(0, i+n)
+}
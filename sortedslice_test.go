@@ -0,0 +1,136 @@
+package order
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice []int
+		value int
+		want  []int
+	}{
+		{slice: nil, value: 1, want: []int{1}},
+		{slice: []int{}, value: 1, want: []int{1}},
+		{slice: []int{2, 3}, value: 1, want: []int{1, 2, 3}},
+		{slice: []int{1, 3}, value: 2, want: []int{1, 2, 3}},
+		{slice: []int{1, 2}, value: 3, want: []int{1, 2, 3}},
+		{slice: []int{1, 2, 2, 3}, value: 2, want: []int{1, 2, 2, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.slice, tt.value), func(t *testing.T) {
+			assert.Equal(t, tt.want, Insert(tt.slice, tt.value))
+		})
+	}
+}
+
+func TestInsert_stable(t *testing.T) {
+	t.Parallel()
+
+	intp := func(i int) *int { return &i }
+
+	one, two := intp(2), intp(2)
+	got := Insert([]*int{intp(1), one, intp(3)}, two).([]*int)
+	// The inserted value should be after the equal value.
+	assert.True(t, got[1] == one)
+	assert.True(t, got[2] == two)
+}
+
+func TestInsert_convert(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []int{1, 2, 3}, intFn.Insert([]int{1, 3}, int8(2)))
+	assert.Equal(t, []int64{1, 2, 3}, intFn.Insert([]int64{1, 3}, int8(2)))
+
+	// Value type can't be converted to the slice element type.
+	assert.Panics(t, func() { intFn.Insert([]int8{1, 3}, 2) })
+	assert.Panics(t, func() { intFn.Insert([]int{1, 3}, "2") })
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice     []int
+		value     int
+		want      []int
+		wantFound bool
+	}{
+		{slice: []int{}, value: 1, want: []int{}},
+		{slice: []int{1, 3}, value: 2, want: []int{1, 3}},
+		{slice: []int{1, 3}, value: 4, want: []int{1, 3}},
+		{slice: []int{1, 2, 3}, value: 1, want: []int{2, 3}, wantFound: true},
+		{slice: []int{1, 2, 3}, value: 2, want: []int{1, 3}, wantFound: true},
+		{slice: []int{1, 2, 3}, value: 3, want: []int{1, 2}, wantFound: true},
+		{slice: []int{1, 2, 2, 3}, value: 2, want: []int{1, 2, 3}, wantFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.slice, tt.value), func(t *testing.T) {
+			got, gotFound := Remove(copySlice(tt.slice), tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFound, gotFound)
+
+			got, gotFound = RemoveLast(copySlice(tt.slice), tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFound, gotFound)
+		})
+	}
+}
+
+func TestRemove_firstLast(t *testing.T) {
+	t.Parallel()
+
+	intp := func(i int) *int { return &i }
+
+	one, two := intp(2), intp(2)
+	slice := []*int{intp(1), one, two, intp(3)}
+
+	got, _ := Remove(copyPtrSlice(slice), intp(2))
+	assert.True(t, got.([]*int)[1] == two)
+
+	got, _ = RemoveLast(copyPtrSlice(slice), intp(2))
+	assert.True(t, got.([]*int)[1] == one)
+}
+
+func TestRemoveAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slice     []int
+		value     int
+		want      []int
+		wantCount int
+	}{
+		{slice: []int{}, value: 1, want: []int{}},
+		{slice: []int{1, 3}, value: 2, want: []int{1, 3}},
+		{slice: []int{1, 2, 3}, value: 2, want: []int{1, 3}, wantCount: 1},
+		{slice: []int{1, 2, 2, 2, 3}, value: 2, want: []int{1, 3}, wantCount: 3},
+		{slice: []int{2, 2}, value: 2, want: []int{}, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.slice, tt.value), func(t *testing.T) {
+			slice := copySlice(tt.slice)
+			got, gotCount := RemoveAll(slice, tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCount, gotCount)
+			// Vacated elements are zeroed.
+			for _, v := range slice[len(tt.want):] {
+				assert.Equal(t, 0, v)
+			}
+		})
+	}
+}
+
+func copyPtrSlice(s []*int) []*int {
+	cp := make([]*int, len(s))
+	copy(cp, s)
+	return cp
+}
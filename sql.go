@@ -0,0 +1,86 @@
+package order
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// Nulls is a policy for ordering null values of the database/sql null types.
+type Nulls int
+
+const (
+	// NullsFirst orders null values before all other values. This is the order of the predefined
+	// comparison functions of the database/sql null types.
+	NullsFirst Nulls = iota
+	// NullsLast orders null values after all other values.
+	NullsLast
+)
+
+// NullString returns comparison functions of sql.NullString values, where nulls are ordered
+// according to the given policy, and are equal to each other.
+func NullString(nulls Nulls) Fns {
+	return By(func(a, b sql.NullString) int {
+		if !a.Valid || !b.Valid {
+			return nulls.compare(a.Valid, b.Valid)
+		}
+		return strings.Compare(a.String, b.String)
+	})
+}
+
+// NullInt64 returns comparison functions of sql.NullInt64 values. See NullString.
+func NullInt64(nulls Nulls) Fns {
+	return By(func(a, b sql.NullInt64) int {
+		if !a.Valid || !b.Valid {
+			return nulls.compare(a.Valid, b.Valid)
+		}
+		return compareInt64(a.Int64, b.Int64)
+	})
+}
+
+// NullFloat64 returns comparison functions of sql.NullFloat64 values. NaN values are ordered as in
+// NaNLast. See NullString.
+func NullFloat64(nulls Nulls) Fns {
+	return By(func(a, b sql.NullFloat64) int {
+		if !a.Valid || !b.Valid {
+			return nulls.compare(a.Valid, b.Valid)
+		}
+		return compareFloat(a.Float64, b.Float64, 1)
+	})
+}
+
+// NullTime returns comparison functions of sql.NullTime values. See NullString.
+func NullTime(nulls Nulls) Fns {
+	return By(func(a, b sql.NullTime) int {
+		if !a.Valid || !b.Valid {
+			return nulls.compare(a.Valid, b.Valid)
+		}
+		return compareTime(a.Time, b.Time)
+	})
+}
+
+// compare compares two values of which at least one is null.
+func (n Nulls) compare(aValid, bValid bool) int {
+	cmp := 0
+	switch {
+	case aValid:
+		cmp = 1
+	case bValid:
+		cmp = -1
+	}
+	if n == NullsLast {
+		cmp = -cmp
+	}
+	return cmp
+}
+
+// compareInt64 is a three-way comparison of int64 values.
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,77 @@
+package order
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullString(t *testing.T) {
+	t.Parallel()
+
+	null := sql.NullString{}
+	a := sql.NullString{String: "a", Valid: true}
+	b := sql.NullString{String: "b", Valid: true}
+
+	slice := []sql.NullString{b, null, a, null}
+	Sort(slice)
+	assert.Equal(t, []sql.NullString{null, null, a, b}, slice)
+
+	NullString(NullsLast).Sort(slice)
+	assert.Equal(t, []sql.NullString{a, b, null, null}, slice)
+
+	// An empty valid string is not null.
+	assert.True(t, Is(sql.NullString{Valid: true}).Greater(null))
+}
+
+func TestNullTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		null, lo, hi interface{}
+		first, last  Fns
+	}{
+		{
+			name:  "int64",
+			null:  sql.NullInt64{},
+			lo:    sql.NullInt64{Int64: math.MinInt64, Valid: true},
+			hi:    sql.NullInt64{Int64: math.MaxInt64, Valid: true},
+			first: NullInt64(NullsFirst),
+			last:  NullInt64(NullsLast),
+		},
+		{
+			name:  "float64",
+			null:  sql.NullFloat64{},
+			lo:    sql.NullFloat64{Float64: -1.5, Valid: true},
+			hi:    sql.NullFloat64{Float64: math.NaN(), Valid: true},
+			first: NullFloat64(NullsFirst),
+			last:  NullFloat64(NullsLast),
+		},
+		{
+			name:  "time",
+			null:  sql.NullTime{},
+			lo:    sql.NullTime{Time: time.Unix(1, 0), Valid: true},
+			hi:    sql.NullTime{Time: time.Unix(2, 0), Valid: true},
+			first: NullTime(NullsFirst),
+			last:  NullTime(NullsLast),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.first.Is(tt.null).Less(tt.lo))
+			assert.True(t, tt.first.Is(tt.lo).Less(tt.hi))
+			assert.True(t, tt.first.Is(tt.null).Equal(tt.null))
+			assert.True(t, tt.last.Is(tt.null).Greater(tt.hi))
+			assert.True(t, tt.last.Is(tt.lo).Less(tt.hi))
+
+			// Predefined functions order nulls first.
+			assert.True(t, Is(tt.null).Less(tt.lo))
+			assert.True(t, Is(tt.lo).Less(tt.hi))
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package order
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Codec defines how records are read from a stream of bytes.
+type Codec interface {
+	// NewDecoder returns a decoder that reads records from the given reader.
+	NewDecoder(r io.Reader) Decoder
+}
+
+// Decoder reads records from a stream.
+type Decoder interface {
+	// Decode returns the next record of the stream. It returns io.EOF when there are no more
+	// records.
+	Decode() (interface{}, error)
+}
+
+// Lines is a Codec that reads each line of the stream as a string record. The line terminator is
+// not included in the record.
+var Lines Codec = LinesFunc(func(line string) (interface{}, error) { return line, nil })
+
+// LinesFunc is a Codec that reads each line of the stream and parses it to a record using the
+// function. The line terminator is not passed to the function.
+type LinesFunc func(line string) (interface{}, error)
+
+// NewDecoder implements the Codec interface.
+func (f LinesFunc) NewDecoder(r io.Reader) Decoder {
+	return &linesDecoder{r: bufio.NewReader(r), parse: f}
+}
+
+type linesDecoder struct {
+	r     *bufio.Reader
+	parse LinesFunc
+}
+
+func (d *linesDecoder) Decode() (interface{}, error) {
+	line, err := d.r.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		// Last line without a line terminator.
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	return d.parse(line)
+}
+
+// SortLines reads the lines of r, sorts them by keys that are parsed from the lines, according to
+// the comparison function, and writes the sorted lines to w. Each written line is terminated by a
+// "\n". The parse function is called exactly once for each line, without its line terminator, and
+// should return a key of type T. If parse is nil, the lines themselves are the keys. The sort is
+// stable, lines with equal keys keep their original order. It returns an error if reading or writing
+// fails, and panics if a returned key is not of type T.
+func SortLines(r io.Reader, w io.Writer, fns Fns, parse func(string) interface{}) error {
+	var lines []string
+	dec := Lines.NewDecoder(r)
+	for {
+		line, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line.(string))
+	}
+
+	key := func(line interface{}) interface{} { return line }
+	if parse != nil {
+		key = func(line interface{}) interface{} { return parse(line.(string)) }
+	}
+	fns.SortByKeys(lines, key)
+
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		if _, err := bw.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ChangeKind is the kind of a Change.
+type ChangeKind int
+
+const (
+	// Removed means that the record exists only in the first stream.
+	Removed ChangeKind = iota
+	// Added means that the record exists only in the second stream.
+	Added
+	// Unchanged means that the record exists in both streams.
+	Unchanged
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Removed:
+		return "removed"
+	case Added:
+		return "added"
+	case Unchanged:
+		return "unchanged"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Change describes a record in the diff of two sorted streams.
+type Change struct {
+	Kind ChangeKind
+	// Value is the record. For unchanged records, this is the record from the first stream.
+	Value interface{}
+}
+
+// DiffReaders reads two sorted streams of records, decoded with the given codec, and calls emit with
+// a Change for each record, in order, without loading the streams into memory. Records are matched
+// one to one, as in Fns.Diff. The streams should be sorted relative to the comparison function. It
+// returns an error if reading a stream fails, or if a stream is found to be out of order.
+func DiffReaders(a, b io.Reader, codec Codec, fns Fns, emit func(Change)) error {
+	sa := &sortedStream{fns: fns, dec: codec.NewDecoder(a)}
+	sb := &sortedStream{fns: fns, dec: codec.NewDecoder(b)}
+	nextA := func() error {
+		if err := sa.next(); err != nil {
+			return fmt.Errorf("first stream: %w", err)
+		}
+		return nil
+	}
+	nextB := func() error {
+		if err := sb.next(); err != nil {
+			return fmt.Errorf("second stream: %w", err)
+		}
+		return nil
+	}
+
+	if err := nextA(); err != nil {
+		return err
+	}
+	if err := nextB(); err != nil {
+		return err
+	}
+	for !sa.eof || !sb.eof {
+		var cmp int
+		switch {
+		case sa.eof:
+			cmp = 1
+		case sb.eof:
+			cmp = -1
+		default:
+			cmp = fns.compare(sa.value, sb.value)
+		}
+
+		var err error
+		switch {
+		case cmp < 0: // a < b
+			emit(Change{Kind: Removed, Value: sa.value.Interface()})
+			err = nextA()
+		case cmp > 0: // a > b
+			emit(Change{Kind: Added, Value: sb.value.Interface()})
+			err = nextB()
+		default: // a == b
+			emit(Change{Kind: Unchanged, Value: sa.value.Interface()})
+			if err = nextA(); err == nil {
+				err = nextB()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateSortedStream returns a function that reads a stream of records, decoded with the given
+// codec, and checks that the records are in a non-decreasing order according to the comparison
+// function. The returned function returns an error describing the first record that is out of order,
+// or the first decoding error.
+func ValidateSortedStream(fns Fns, codec Codec) func(r io.Reader) error {
+	return func(r io.Reader) error {
+		s := &sortedStream{fns: fns, dec: codec.NewDecoder(r)}
+		for !s.eof {
+			if err := s.next(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// NewSortedWriter returns a writer that writes to w, and checks that the written stream of records,
+// decoded with the given codec, is in a non-decreasing order according to the comparison function.
+// Validation is done concurrently with the writes, such that an out of order record is reported by
+// the write of the record or by one of the following writes. Close must be called after the last
+// write, and returns the validation error if it was not reported yet. Close does not close w.
+func NewSortedWriter(w io.Writer, fns Fns, codec Codec) io.WriteCloser {
+	pr, pw := io.Pipe()
+	s := &sortedWriter{w: w, pw: pw, done: make(chan struct{})}
+	validate := ValidateSortedStream(fns, codec)
+	go func() {
+		var err error
+		defer func() {
+			// A panic can't be recovered by the caller of this goroutine, report it as an error.
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+			// Fail the following writes to the pipe with the validation error.
+			pr.CloseWithError(err)
+			s.err = err
+			close(s.done)
+		}()
+		err = validate(pr)
+	}()
+	return s
+}
+
+type sortedWriter struct {
+	w  io.Writer
+	pw *io.PipeWriter
+	// done is closed when the validation is done, after err is set.
+	done chan struct{}
+	// err is the validation error.
+	err error
+}
+
+func (s *sortedWriter) Write(p []byte) (int, error) {
+	// Write to the validation pipe first, to avoid writing data which was found to be out of order.
+	if _, err := s.pw.Write(p); err != nil {
+		return 0, err
+	}
+	return s.w.Write(p)
+}
+
+// Close waits for the validation to finish and returns its error. Following calls return the same
+// error.
+func (s *sortedWriter) Close() error {
+	s.pw.Close()
+	<-s.done
+	return s.err
+}
+
+// sortedStream reads records from a decoder, and checks that they are in order.
+type sortedStream struct {
+	fns Fns
+	dec Decoder
+	// n is the number of records that were read.
+	n int
+	// value is the last record that was read.
+	value reflect.Value
+	// eof is set when there are no more records in the stream.
+	eof bool
+}
+
+// next reads the next record. It returns an error if the decoding failed or if the record is less
+// than the previous record.
+func (s *sortedStream) next() error {
+	v, err := s.dec.Decode()
+	if err == io.EOF {
+		s.eof = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("record %d: %w", s.n+1, err)
+	}
+	value := s.fns.mustValue(reflect.ValueOf(v))
+	if s.n > 0 && s.fns.compare(s.value, value) > 0 {
+		return fmt.Errorf("record %d is out of order: less than record %d", s.n+1, s.n)
+	}
+	s.n++
+	s.value = value
+	return nil
+}
@@ -0,0 +1,223 @@
+package order
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var stringFn = By(strings.Compare)
+
+var intLines = LinesFunc(func(line string) (interface{}, error) { return strconv.Atoi(line) })
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	dec := Lines.NewDecoder(strings.NewReader("a\nb\r\n\nc"))
+	var got []interface{}
+	for {
+		v, err := dec.Decode()
+		if err != nil {
+			break
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, []interface{}{"a", "b", "", "c"}, got)
+}
+
+func TestDiffReaders(t *testing.T) {
+	t.Parallel()
+
+	var got []Change
+	err := DiffReaders(
+		strings.NewReader("a\nb\nb\nd\n"),
+		strings.NewReader("b\nc\nd\ne\nf"),
+		Lines, stringFn, func(c Change) { got = append(got, c) })
+	require.NoError(t, err)
+
+	want := []Change{
+		{Kind: Removed, Value: "a"},
+		{Kind: Unchanged, Value: "b"},
+		{Kind: Removed, Value: "b"},
+		{Kind: Added, Value: "c"},
+		{Kind: Unchanged, Value: "d"},
+		{Kind: Added, Value: "e"},
+		{Kind: Added, Value: "f"},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestDiffReaders_empty(t *testing.T) {
+	t.Parallel()
+
+	var got []Change
+	err := DiffReaders(strings.NewReader(""), strings.NewReader(""), Lines, stringFn,
+		func(c Change) { got = append(got, c) })
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestDiffReaders_failures(t *testing.T) {
+	t.Parallel()
+
+	emit := func(Change) {}
+
+	// Out of order stream.
+	err := DiffReaders(strings.NewReader("1\n3\n2\n"), strings.NewReader("1\n"), intLines, intFn, emit)
+	assert.EqualError(t, err, "first stream: record 3 is out of order: less than record 2")
+
+	// Decode error.
+	err = DiffReaders(strings.NewReader("1\n"), strings.NewReader("1\nx\n"), intLines, intFn, emit)
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(err, &numErr))
+	assert.Contains(t, err.Error(), "second stream: record 2")
+
+	// Wrong record type.
+	assert.Panics(t, func() {
+		DiffReaders(strings.NewReader("a\n"), strings.NewReader("a\n"), Lines, intFn, emit)
+	})
+}
+
+func TestValidateSortedStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		stream  string
+		wantErr string
+	}{
+		{name: "empty", stream: ""},
+		{name: "sorted", stream: "1\n2\n2\n3\n"},
+		{name: "out of order", stream: "1\n2\n3\n1\n4\n0\n", wantErr: "record 4 is out of order: less than record 3"},
+		{name: "decode error", stream: "1\nx\n", wantErr: `record 2: strconv.Atoi: parsing "x": invalid syntax`},
+	}
+
+	validate := ValidateSortedStream(intFn, intLines)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(strings.NewReader(tt.stream))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSortedWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sorted", func(t *testing.T) {
+		var out strings.Builder
+		w := NewSortedWriter(&out, intFn, intLines)
+		for _, line := range []string{"1\n", "2\n2", "\n3\n"} {
+			_, err := w.Write([]byte(line))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		assert.Equal(t, "1\n2\n2\n3\n", out.String())
+		// Close can be called more than once.
+		require.NoError(t, w.Close())
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		var out strings.Builder
+		w := NewSortedWriter(&out, intFn, intLines)
+		var err error
+		for _, line := range []string{"1\n", "3\n", "2\n", "4\n", "5\n"} {
+			if _, err = w.Write([]byte(line)); err != nil {
+				break
+			}
+		}
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+		assert.EqualError(t, err, "record 3 is out of order: less than record 2")
+		// The record which was found out of order is not written.
+		assert.NotContains(t, out.String(), "5")
+	})
+
+	t.Run("wrong record type", func(t *testing.T) {
+		w := NewSortedWriter(&strings.Builder{}, intFn, Lines)
+		w.Write([]byte("a\n"))
+		err := w.Close()
+		assert.Error(t, err)
+		assert.Equal(t, err, w.Close())
+	})
+}
+
+func TestSortLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		in    string
+		fns   Fns
+		parse func(string) interface{}
+		want  string
+	}{
+		{name: "empty", in: "", fns: stringFn, want: ""},
+		{name: "lines", in: "b\nc\r\na", fns: stringFn, want: "a\nb\nc\n"},
+		{
+			name: "parsed keys",
+			in:   "10\n9\n100\n",
+			fns:  intFn,
+			parse: func(line string) interface{} {
+				i, _ := strconv.Atoi(line)
+				return i
+			},
+			want: "9\n10\n100\n",
+		},
+		{
+			name:  "stable",
+			in:    "b 1\na 2\nb 3\na 4\n",
+			fns:   stringFn,
+			parse: func(line string) interface{} { return strings.Fields(line)[0] },
+			want:  "a 2\na 4\nb 1\nb 3\n",
+		},
+		{name: "reversed", in: "a\nc\nb\n", fns: stringFn.Reversed(), want: "c\nb\na\n"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var w strings.Builder
+			err := SortLines(strings.NewReader(tt.in), &w, tt.fns, tt.parse)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, w.String())
+		})
+	}
+}
+
+func TestSortLines_failures(t *testing.T) {
+	t.Parallel()
+
+	// Wrong key type.
+	assert.Panics(t, func() {
+		SortLines(strings.NewReader("a\n"), &strings.Builder{}, intFn, nil)
+	})
+
+	// Write error.
+	err := SortLines(strings.NewReader("a\n"), failingWriter{}, stringFn, nil)
+	assert.EqualError(t, err, "write failed")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestChangeKind_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "removed", Removed.String())
+	assert.Equal(t, "added", Added.String())
+	assert.Equal(t, "unchanged", Unchanged.String())
+	assert.Equal(t, "ChangeKind(5)", ChangeKind(5).String())
+}
@@ -0,0 +1,147 @@
+package order
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Natural returns comparison functions of strings in a natural order, where runs of digits are
+// compared by their numeric value, such that "file2" < "file10". Other parts of the strings are
+// compared byte-wise. Numbers that are equal in value are ordered by their number of leading zeros,
+// the one with less zeros first, and strings that are otherwise equal are compared byte-wise, such
+// that only equal strings are equal.
+func Natural() Fns {
+	return By(compareNatural)
+}
+
+// compareNatural is a three-way natural order comparison of strings.
+func compareNatural(a, b string) int {
+	zeros := 0
+	for a != "" && b != "" {
+		aDigit, bDigit := isDigit(a[0]), isDigit(b[0])
+		switch {
+		case aDigit && bDigit:
+			var aNum, bNum string
+			aNum, a = splitDigits(a)
+			bNum, b = splitDigits(b)
+			// Compare the numbers without their leading zeros: by length and then by digits.
+			aTrim, bTrim := strings.TrimLeft(aNum, "0"), strings.TrimLeft(bNum, "0")
+			if len(aTrim) != len(bTrim) {
+				return sign(len(aTrim) - len(bTrim))
+			}
+			if cmp := strings.Compare(aTrim, bTrim); cmp != 0 {
+				return cmp
+			}
+			if zeros == 0 {
+				zeros = sign(len(aNum) - len(bNum))
+			}
+		case aDigit || bDigit:
+			// A number and a non-digit are compared byte-wise.
+			return strings.Compare(a[:1], b[:1])
+		default:
+			if a[0] != b[0] {
+				return strings.Compare(a[:1], b[:1])
+			}
+			a, b = a[1:], b[1:]
+		}
+	}
+	if cmp := sign(len(a) - len(b)); cmp != 0 {
+		return cmp
+	}
+	return zeros
+}
+
+// StringsFold returns comparison functions of strings that ignore case, using Unicode case folding.
+// Strings are equal if they are equal under case folding, as in strings.EqualFold. Otherwise, they
+// are compared by their first differing runes, after mapping each rune to the smallest rune of its
+// case folding orbit.
+func StringsFold() Fns {
+	return By(compareFold)
+}
+
+// compareFold is a three-way comparison of strings under Unicode case folding.
+func compareFold(a, b string) int {
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		a, b = a[na:], b[nb:]
+		if ra == rb {
+			continue
+		}
+		if fa, fb := foldRune(ra), foldRune(rb); fa != fb {
+			return sign(int(fa) - int(fb))
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+// foldRune returns the smallest rune that is equivalent to r under Unicode case folding.
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// StringsStripAccents returns comparison functions of strings that ignore accents and other
+// diacritics, such that "résumé" and "resume" are equal. Strings are decomposed, their combining
+// marks are removed, and the results are compared byte-wise. The returned functions share a single
+// transformer, which is not safe for concurrent use, such that comparisons of non-ASCII strings are
+// serialized.
+func StringsStripAccents() Fns {
+	s := &accentStripper{t: transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)}
+	return By(func(a, b string) int { return strings.Compare(s.strip(a), s.strip(b)) })
+}
+
+// accentStripper removes the combining marks from strings.
+type accentStripper struct {
+	mu sync.Mutex
+	t  transform.Transformer
+}
+
+// strip removes the combining marks from a string.
+func (s *accentStripper) strip(str string) string {
+	if isASCII(str) {
+		// ASCII strings have no combining marks.
+		return str
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stripped, _, err := transform.String(s.t, str)
+	if err != nil {
+		// Fall back to comparing the original string.
+		return str
+	}
+	return stripped
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// splitDigits splits the leading run of digits from a string.
+func splitDigits(s string) (digits, rest string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
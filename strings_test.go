@@ -0,0 +1,114 @@
+package order
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNatural(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "", b: "a", want: -1},
+		{a: "file2", b: "file10", want: -1},
+		{a: "file10", b: "file10", want: 0},
+		{a: "file10", b: "file9", want: 1},
+		{a: "file", b: "file1", want: -1},
+		{a: "a1b2", b: "a1b10", want: -1},
+		{a: "1", b: "a", want: -1},
+		{a: "1", b: " ", want: 1},
+		{a: "x1", b: "xa", want: -1},
+		{a: "a", b: "b", want: -1},
+		{a: "01", b: "1", want: 1},
+		{a: "01a", b: "1b", want: -1},
+		{a: "v1.010", b: "v1.9", want: 1},
+		{a: "99999999999999999999999", b: "100000000000000000000000", want: -1},
+	}
+
+	fns := Natural()
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, sign(compareNatural(tt.a, tt.b)))
+			assert.Equal(t, -tt.want, sign(compareNatural(tt.b, tt.a)))
+		})
+	}
+
+	files := []string{"file10.txt", "file2.txt", "file1.txt", "file01.txt"}
+	fns.Sort(files)
+	assert.Equal(t, []string{"file1.txt", "file01.txt", "file2.txt", "file10.txt"}, files)
+}
+
+func TestStringsFold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "Go", b: "GO", want: 0},
+		{a: "apple", b: "Banana", want: -1},
+		{a: "Zebra", b: "apple", want: 1},
+		{a: "go", b: "gopher", want: -1},
+		// Unicode case folding: Kelvin sign, long s and sigma forms.
+		{a: "\u212a", b: "k", want: 0},
+		{a: "\u017f", b: "S", want: 0},
+		{a: "\u03a3", b: "\u03c2", want: 0},
+		{a: "\u00e9", b: "\u00c9", want: 0},
+		{a: "e", b: "\u00c9", want: -1},
+	}
+
+	fns := StringsFold()
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, fns.compare(reflect.ValueOf(tt.a), reflect.ValueOf(tt.b)))
+			assert.Equal(t, -tt.want, fns.compare(reflect.ValueOf(tt.b), reflect.ValueOf(tt.a)))
+			assert.Equal(t, tt.want == 0, strings.EqualFold(tt.a, tt.b))
+		})
+	}
+
+	names := []string{"bob", "Alice", "carol", "alice"}
+	fns.SortStable(names)
+	assert.Equal(t, []string{"Alice", "alice", "bob", "carol"}, names)
+}
+
+func TestStringsStripAccents(t *testing.T) {
+	t.Parallel()
+
+	fns := StringsStripAccents()
+	assert.True(t, fns.Is("résumé").Equal("resume"))
+	assert.True(t, fns.Is("Ångström").Equal("Angstrom"))
+	assert.True(t, fns.Is("naïve").Less("nb"))
+	assert.True(t, fns.Is("é").Greater("d"))
+	// Case is not ignored.
+	assert.False(t, fns.Is("É").Equal("e"))
+
+	words := []string{"éclair", "eclat", "ecarte"}
+	fns.Sort(words)
+	assert.Equal(t, []string{"ecarte", "éclair", "eclat"}, words)
+}
+
+func TestStringsStripAccents_concurrent(t *testing.T) {
+	t.Parallel()
+
+	fns := StringsStripAccents()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			words := []string{"éclair", "eclat", "ecarte", "élan", "ecole"}
+			fns.Sort(words)
+			assert.Equal(t, []string{"ecarte", "éclair", "eclat", "ecole", "élan"}, words)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+package order
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ByText returns comparison functions of values of types that implement encoding.TextMarshaler,
+// which compare values by the bytes of their text representation. This gives a deterministic order
+// for types that don't have a natural order, such as some ID and enum types. The text
+// representation of non-pointer values of types that can be used as map keys, and that don't hold
+// interface values, is cached by the returned functions, so a new comparison functions object should
+// be created for each data set. The cache holds up to textCacheSize values, and it is cleared when
+// it is full. It panics when comparing values if marshaling the text fails.
+func ByText() Fns {
+	cache := &textCache{texts: make(map[interface{}][]byte), cacheable: make(map[reflect.Type]bool)}
+	return By(func(a, b encoding.TextMarshaler) int {
+		return bytes.Compare(cache.marshal(a), cache.marshal(b))
+	})
+}
+
+// textCacheSize is the maximal number of text representations that are cached by ByText functions.
+const textCacheSize = 4096
+
+// textCache caches text representations of values.
+type textCache struct {
+	mu    sync.Mutex
+	texts map[interface{}][]byte
+	// cacheable caches whether values of a type can be cached. See isHashable.
+	cacheable map[reflect.Type]bool
+}
+
+// marshal returns the text representation of a value, using and filling the cache for values that
+// can be cached.
+func (c *textCache) marshal(v encoding.TextMarshaler) []byte {
+	tp := reflect.TypeOf(v)
+	c.mu.Lock()
+	cacheable, ok := c.cacheable[tp]
+	if !ok {
+		cacheable = tp.Kind() != reflect.Ptr && isHashable(tp)
+		c.cacheable[tp] = cacheable
+	}
+	if cacheable {
+		if text, ok := c.texts[v]; ok {
+			c.mu.Unlock()
+			return text
+		}
+	}
+	c.mu.Unlock()
+
+	text, err := v.MarshalText()
+	if err != nil {
+		panic(fmt.Sprintf("marshal %v: %v", tp, err))
+	}
+	if cacheable {
+		c.mu.Lock()
+		if len(c.texts) >= textCacheSize {
+			c.texts = make(map[interface{}][]byte)
+		}
+		c.texts[v] = text
+		c.mu.Unlock()
+	}
+	return text
+}
+
+// isHashable returns whether all values of the given type can be used as map keys. Values of
+// comparable types that hold interface values can't be used as map keys if the interface values are
+// not comparable.
+func isHashable(tp reflect.Type) bool {
+	switch tp.Kind() {
+	case reflect.Interface, reflect.Map, reflect.Slice, reflect.Func:
+		return false
+	case reflect.Array:
+		return isHashable(tp.Elem())
+	case reflect.Struct:
+		for i := 0; i < tp.NumField(); i++ {
+			if !isHashable(tp.Field(i).Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// level is a text marshaler which has no natural order.
+type level struct{ name string }
+
+func (l level) MarshalText() ([]byte, error) {
+	if l.name == "" {
+		return nil, errors.New("empty level")
+	}
+	return []byte(l.name), nil
+}
+
+// countingText counts the times its text was marshaled.
+type countingText struct {
+	text  string
+	count *int
+}
+
+func (c countingText) MarshalText() ([]byte, error) {
+	*c.count++
+	return []byte(c.text), nil
+}
+
+func TestByText(t *testing.T) {
+	t.Parallel()
+
+	levels := []level{{"warn"}, {"debug"}, {"info"}}
+	ByText().Sort(levels)
+	assert.Equal(t, []level{{"debug"}, {"info"}, {"warn"}}, levels)
+
+	// Pointers to text marshalers.
+	ptrs := []*level{{"b"}, {"a"}}
+	ByText().Sort(ptrs)
+	assert.Equal(t, "a", ptrs[0].name)
+
+	// Different types could be compared with the same functions.
+	fns := ByText()
+	assert.True(t, fns.Is(level{"a"}).Less(level{"b"}))
+	assert.True(t, fns.Is(level{"b"}).Equal(countingText{text: "b", count: new(int)}))
+}
+
+func TestByText_cache(t *testing.T) {
+	t.Parallel()
+
+	var count int
+	a, b := countingText{text: "a", count: &count}, countingText{text: "b", count: &count}
+	fns := ByText()
+	for i := 0; i < 3; i++ {
+		assert.True(t, fns.Is(a).Less(b))
+	}
+	assert.Equal(t, 2, count)
+}
+
+// anyText is a comparable text marshaler which holds an interface value.
+type anyText struct{ v interface{} }
+
+func (a anyText) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprint(a.v)), nil
+}
+
+func TestByText_notHashable(t *testing.T) {
+	t.Parallel()
+
+	values := []anyText{{[]int{2}}, {[]int{1}}, {3}}
+	ByText().Sort(values)
+	assert.Equal(t, []anyText{{3}, {[]int{1}}, {[]int{2}}}, values)
+}
+
+func TestByText_cacheSize(t *testing.T) {
+	t.Parallel()
+
+	var count int
+	fns := ByText()
+	first := countingText{text: "0", count: &count}
+	fns.Is(first).Equal(first)
+	for i := 0; i < textCacheSize; i++ {
+		v := countingText{text: strconv.Itoa(i + 1), count: &count}
+		fns.Is(v).Equal(v)
+	}
+	// The cache was cleared when it was full.
+	assert.Equal(t, textCacheSize+1, count)
+	fns.Is(first).Equal(first)
+	assert.Equal(t, textCacheSize+2, count)
+}
+
+func TestByText_failures(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { ByText().Sort([]int{2, 1}) })
+	assert.Panics(t, func() { ByText().Sort([]level{{"a"}, {""}}) })
+}
@@ -0,0 +1,73 @@
+package order
+
+import (
+	"reflect"
+	"time"
+)
+
+// TimedValue is a value with a time stamp.
+type TimedValue struct {
+	Time  time.Time
+	Value interface{}
+}
+
+// TimeWindowBuffer holds time stamped values ordered by their time, and drops values that are older
+// than a maximal age. A TimeWindowBuffer is not safe for concurrent use.
+type TimeWindowBuffer struct {
+	maxAge time.Duration
+	clock  func() time.Time
+	list   *SortedList
+}
+
+// timedValueFn orders TimedValue values by their time.
+var timedValueFn = By(func(a, b TimedValue) int { return compareTime(a.Time, b.Time) })
+
+// NewTimeWindowBuffer returns an empty TimeWindowBuffer that drops values that are older than
+// maxAge, relative to the time returned by clock. If clock is nil, time.Now is used.
+func NewTimeWindowBuffer(maxAge time.Duration, clock func() time.Time) *TimeWindowBuffer {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &TimeWindowBuffer{maxAge: maxAge, clock: clock, list: NewSortedList(timedValueFn)}
+}
+
+// Add adds a value with a given time stamp to the buffer. Values with equal time stamps are kept in
+// the order they were added. A value that is already expired is dropped.
+func (b *TimeWindowBuffer) Add(t time.Time, value interface{}) {
+	b.expire()
+	if t.Before(b.cutoff()) {
+		return
+	}
+	b.list.Insert(TimedValue{Time: t, Value: value})
+}
+
+// Len returns the number of values in the buffer that are not expired.
+func (b *TimeWindowBuffer) Len() int {
+	b.expire()
+	return b.list.Len()
+}
+
+// Range calls f sequentially, in time order, for each value in the buffer that is not expired and
+// its time stamp is in the range [since, until). If f returns false, range stops the iteration. The
+// buffer should not be modified during the iteration.
+func (b *TimeWindowBuffer) Range(since, until time.Time, f func(TimedValue) bool) {
+	b.expire()
+	start := b.list.lowerBound(reflect.ValueOf(TimedValue{Time: since}))
+	end := b.list.lowerBound(reflect.ValueOf(TimedValue{Time: until}))
+	for i := start; i < end; i++ {
+		if !f(b.list.At(i).(TimedValue)) {
+			return
+		}
+	}
+}
+
+// cutoff returns the time stamp before which values are expired.
+func (b *TimeWindowBuffer) cutoff() time.Time {
+	return b.clock().Add(-b.maxAge)
+}
+
+// expire drops the expired values.
+func (b *TimeWindowBuffer) expire() {
+	i := b.list.lowerBound(reflect.ValueOf(TimedValue{Time: b.cutoff()}))
+	b.list.deleteRange(0, i)
+}
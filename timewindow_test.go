@@ -0,0 +1,67 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeWindowBuffer(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+	now := start
+	clock := func() time.Time { return now }
+	at := func(sec int) time.Time { return start.Add(time.Duration(sec) * time.Second) }
+
+	b := NewTimeWindowBuffer(10*time.Second, clock)
+	assert.Equal(t, 0, b.Len())
+
+	b.Add(at(0), "a")
+	b.Add(at(2), "c")
+	b.Add(at(1), "b")
+	b.Add(at(1), "b2")
+	// An expired value is dropped.
+	b.Add(at(-11), "expired")
+	assert.Equal(t, 4, b.Len())
+	assert.Equal(t, []interface{}{"a", "b", "b2", "c"}, bufferValues(b, at(-100), at(100)))
+	assert.Equal(t, []interface{}{"b", "b2"}, bufferValues(b, at(1), at(2)))
+	assert.Empty(t, bufferValues(b, at(3), at(4)))
+
+	// Advance the clock such that the first value expires. A value is expired only when it is older
+	// than the maximal age.
+	now = at(10)
+	assert.Equal(t, 4, b.Len())
+	now = at(11)
+	assert.Equal(t, 3, b.Len())
+	assert.Equal(t, []interface{}{"b", "b2", "c"}, bufferValues(b, at(-100), at(100)))
+
+	now = at(100)
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestTimeWindowBuffer_rangeStop(t *testing.T) {
+	t.Parallel()
+
+	b := NewTimeWindowBuffer(time.Hour, nil)
+	now := time.Now()
+	b.Add(now, 1)
+	b.Add(now, 2)
+
+	var got []interface{}
+	b.Range(now.Add(-time.Minute), now.Add(time.Minute), func(v TimedValue) bool {
+		got = append(got, v.Value)
+		return false
+	})
+	assert.Equal(t, []interface{}{1}, got)
+}
+
+func bufferValues(b *TimeWindowBuffer, since, until time.Time) []interface{} {
+	var values []interface{}
+	b.Range(since, until, func(v TimedValue) bool {
+		values = append(values, v.Value)
+		return true
+	})
+	return values
+}
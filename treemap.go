@@ -0,0 +1,139 @@
+package order
+
+import (
+	"reflect"
+	"sort"
+)
+
+// TreeMap is a map from keys of type T to values, where keys are ordered by comparison functions.
+// Keys are equal if the comparison functions return zero for them. It is backed by sorted slices,
+// as SortedList, such that lookups take O(log n) time, and insertions and deletions take O(n) time.
+// This suits maps that are read more than they are modified, since iterations and lookups access
+// contiguous memory. For write heavy workloads of values without associated data, see SkipList. The
+// keys in order are iterated by Range, and the keys of a range of the map by RangeBetween, as in
+// Index. A TreeMap is not safe for concurrent use.
+type TreeMap struct {
+	fns    Fns
+	keys   []interface{}
+	values []interface{}
+}
+
+// NewTreeMap returns an empty TreeMap with keys of type T, ordered by the given comparison
+// functions.
+func NewTreeMap(fns Fns) *TreeMap {
+	return &TreeMap{fns: fns}
+}
+
+// Put sets the value of a key. If the map already contains an equal key, its key and value are
+// replaced. It panics if the key is not of type T.
+func (m *TreeMap) Put(key, value interface{}) {
+	k := m.fns.mustValue(reflect.ValueOf(key))
+	i := m.lowerBound(k)
+	if i < len(m.keys) && m.compare(i, k) == 0 {
+		m.keys[i], m.values[i] = key, value
+		return
+	}
+	m.keys = append(m.keys, nil)
+	m.values = append(m.values, nil)
+	copy(m.keys[i+1:], m.keys[i:])
+	copy(m.values[i+1:], m.values[i:])
+	m.keys[i], m.values[i] = key, value
+}
+
+// Get returns the value of a key that is equal to the given key, and whether such key exists.
+func (m *TreeMap) Get(key interface{}) (value interface{}, ok bool) {
+	i := m.search(key)
+	if i < 0 {
+		return nil, false
+	}
+	return m.values[i], true
+}
+
+// Delete deletes the key that is equal to the given key and its value. It returns whether a key was
+// deleted.
+func (m *TreeMap) Delete(key interface{}) bool {
+	i := m.search(key)
+	if i < 0 {
+		return false
+	}
+	copy(m.keys[i:], m.keys[i+1:])
+	copy(m.values[i:], m.values[i+1:])
+	// Allow garbage collection of the deleted key and value.
+	n := len(m.keys) - 1
+	m.keys[n], m.values[n] = nil, nil
+	m.keys, m.values = m.keys[:n], m.values[:n]
+	return true
+}
+
+// Floor returns the greatest key that is less than or equal to the given key, and its value. It
+// returns ok false if there is no such key.
+func (m *TreeMap) Floor(key interface{}) (k, value interface{}, ok bool) {
+	i := m.upperBound(m.fns.mustValue(reflect.ValueOf(key))) - 1
+	if i < 0 {
+		return nil, nil, false
+	}
+	return m.keys[i], m.values[i], true
+}
+
+// Ceil returns the smallest key that is greater than or equal to the given key, and its value. It
+// returns ok false if there is no such key.
+func (m *TreeMap) Ceil(key interface{}) (k, value interface{}, ok bool) {
+	i := m.lowerBound(m.fns.mustValue(reflect.ValueOf(key)))
+	if i == len(m.keys) {
+		return nil, nil, false
+	}
+	return m.keys[i], m.values[i], true
+}
+
+// Len returns the number of keys in the map.
+func (m *TreeMap) Len() int {
+	return len(m.keys)
+}
+
+// Range calls f sequentially for each key and value in the map, in the order of the keys. If f
+// returns false, range stops the iteration. The map should not be modified during the iteration.
+func (m *TreeMap) Range(f func(key, value interface{}) bool) {
+	m.rangeIndex(0, len(m.keys), f)
+}
+
+// RangeBetween calls f sequentially for each key in the range [from, to) and its value, in the order
+// of the keys. If f returns false, range stops the iteration. The map should not be modified during
+// the iteration.
+func (m *TreeMap) RangeBetween(from, to interface{}, f func(key, value interface{}) bool) {
+	start := m.lowerBound(m.fns.mustValue(reflect.ValueOf(from)))
+	end := m.lowerBound(m.fns.mustValue(reflect.ValueOf(to)))
+	m.rangeIndex(start, end, f)
+}
+
+func (m *TreeMap) rangeIndex(start, end int, f func(key, value interface{}) bool) {
+	for i := start; i < end; i++ {
+		if !f(m.keys[i], m.values[i]) {
+			return
+		}
+	}
+}
+
+// search returns the index of the key that is equal to the given key, or -1 if there is no such key.
+func (m *TreeMap) search(key interface{}) int {
+	k := m.fns.mustValue(reflect.ValueOf(key))
+	i := m.lowerBound(k)
+	if i == len(m.keys) || m.compare(i, k) != 0 {
+		return -1
+	}
+	return i
+}
+
+// compare compares the i'th key of the map with k.
+func (m *TreeMap) compare(i int, k reflect.Value) int {
+	return m.fns.compare(reflect.ValueOf(m.keys[i]), k)
+}
+
+// lowerBound returns the index of the first key that is not less than k.
+func (m *TreeMap) lowerBound(k reflect.Value) int {
+	return sort.Search(len(m.keys), func(i int) bool { return m.compare(i, k) >= 0 })
+}
+
+// upperBound returns the index of the first key that is greater than k.
+func (m *TreeMap) upperBound(k reflect.Value) int {
+	return sort.Search(len(m.keys), func(i int) bool { return m.compare(i, k) > 0 })
+}
@@ -0,0 +1,108 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeMap(t *testing.T) {
+	t.Parallel()
+
+	m := NewTreeMap(intFn)
+	assert.Equal(t, 0, m.Len())
+	_, ok := m.Get(1)
+	assert.False(t, ok)
+	assert.False(t, m.Delete(1))
+
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(5, "e")
+	m.Put(1, "A")
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, [][2]interface{}{{1, "A"}, {3, "c"}, {5, "e"}}, mapEntries(m))
+
+	v, ok := m.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "c", v)
+
+	assert.True(t, m.Delete(3))
+	assert.False(t, m.Delete(3))
+	assert.Equal(t, [][2]interface{}{{1, "A"}, {5, "e"}}, mapEntries(m))
+}
+
+func TestTreeMap_floorCeil(t *testing.T) {
+	t.Parallel()
+
+	m := NewTreeMap(intFn)
+	for _, k := range []int{10, 20, 30} {
+		m.Put(k, k*10)
+	}
+
+	tests := []struct {
+		key                 int
+		wantFloor, wantCeil interface{}
+	}{
+		{key: 5, wantFloor: nil, wantCeil: 10},
+		{key: 10, wantFloor: 10, wantCeil: 10},
+		{key: 15, wantFloor: 10, wantCeil: 20},
+		{key: 30, wantFloor: 30, wantCeil: 30},
+		{key: 35, wantFloor: 30, wantCeil: nil},
+	}
+
+	for _, tt := range tests {
+		k, v, ok := m.Floor(tt.key)
+		assert.Equal(t, tt.wantFloor, k, "floor of %d", tt.key)
+		assert.Equal(t, tt.wantFloor != nil, ok)
+		if ok {
+			assert.Equal(t, k.(int)*10, v)
+		}
+		k, v, ok = m.Ceil(tt.key)
+		assert.Equal(t, tt.wantCeil, k, "ceil of %d", tt.key)
+		assert.Equal(t, tt.wantCeil != nil, ok)
+		if ok {
+			assert.Equal(t, k.(int)*10, v)
+		}
+	}
+}
+
+func TestTreeMap_rangeBetween(t *testing.T) {
+	t.Parallel()
+
+	m := NewTreeMap(intFn)
+	for _, k := range []int{1, 2, 3, 4, 5} {
+		m.Put(k, nil)
+	}
+
+	var got []interface{}
+	m.RangeBetween(2, 4, func(k, _ interface{}) bool {
+		got = append(got, k)
+		return true
+	})
+	assert.Equal(t, []interface{}{2, 3}, got)
+
+	got = nil
+	m.RangeBetween(0, 10, func(k, _ interface{}) bool {
+		got = append(got, k)
+		return len(got) < 2
+	})
+	assert.Equal(t, []interface{}{1, 2}, got)
+}
+
+func TestTreeMap_invalidKey(t *testing.T) {
+	t.Parallel()
+
+	m := NewTreeMap(intFn)
+	assert.Panics(t, func() { m.Put("1", nil) })
+	assert.Panics(t, func() { m.Get("1") })
+	assert.Panics(t, func() { m.Floor("1") })
+}
+
+func mapEntries(m *TreeMap) [][2]interface{} {
+	var entries [][2]interface{}
+	m.Range(func(k, v interface{}) bool {
+		entries = append(entries, [2]interface{}{k, v})
+		return true
+	})
+	return entries
+}